@@ -0,0 +1,93 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package shellwords_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/internal/shellwords"
+)
+
+func TestSplit(t *testing.T) {
+	testcases := map[string]struct {
+		input  string
+		expect []string
+		err    bool
+	}{
+		"empty": {
+			input:  "  ",
+			expect: nil,
+		},
+		"plain words": {
+			input:  "workflow list  --limit 10",
+			expect: []string{"workflow", "list", "--limit", "10"},
+		},
+		"single quotes": {
+			input:  `workflow list --query 'WorkflowType = "foo"'`,
+			expect: []string{"workflow", "list", "--query", `WorkflowType = "foo"`},
+		},
+		"double quotes with escapes": {
+			input:  `echo "a \"b\" \c"`,
+			expect: []string{"echo", `a "b" \c`},
+		},
+		"escaped space": {
+			input:  `less -P foo\ bar`,
+			expect: []string{"less", "-P", "foo bar"},
+		},
+		"adjacent quotes": {
+			input:  `--paging='al'"ways"`,
+			expect: []string{"--paging=always"},
+		},
+		"unterminated single quote": {
+			input: `list 'foo`,
+			err:   true,
+		},
+		"unterminated double quote": {
+			input: `list "foo`,
+			err:   true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			words, err := shellwords.Split(tc.input)
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expect, words)
+			}
+		})
+	}
+}
+
+func TestJoinRoundTrip(t *testing.T) {
+	words := []string{"workflow", "list", "--query", `Status = 'Running'`, "", "a b"}
+
+	line := shellwords.Join(words)
+	split, err := shellwords.Split(line)
+
+	assert.NoError(t, err)
+	assert.Equal(t, words, split)
+}
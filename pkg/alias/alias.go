@@ -0,0 +1,129 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alias
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/temporalio/tctl-kit/internal/shellwords"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+// Run expands command aliases in args and runs the app with the result.
+func Run(app *cli.App, cfg *config.Config, args []string) error {
+	args, err := Expand(app, cfg, args)
+	if err != nil {
+		return err
+	}
+
+	return app.Run(args)
+}
+
+// Expand replaces the command alias in args with its definition from the config.
+// args are expected in the os.Args form, starting with the program name.
+// Aliases never shadow the app commands and may refer to other aliases.
+func Expand(app *cli.App, cfg *config.Config, args []string) ([]string, error) {
+	return expand(app, cfg.Aliases, args)
+}
+
+func expand(app *cli.App, aliases map[string]string, args []string) ([]string, error) {
+	var chain []string
+
+	for {
+		pos := commandIndex(app, args)
+		if pos < 0 {
+			return args, nil
+		}
+
+		name := args[pos]
+		definition, ok := aliases[name]
+		if !ok || app.Command(name) != nil {
+			return args, nil
+		}
+
+		for _, prev := range chain {
+			if prev == name {
+				return nil, fmt.Errorf("recursive alias: %v", strings.Join(append(chain, name), " -> "))
+			}
+		}
+		chain = append(chain, name)
+
+		words, err := shellwords.Split(definition)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse alias %v: %w", name, err)
+		}
+		if len(words) == 0 {
+			return nil, fmt.Errorf("alias %v is empty", name)
+		}
+
+		expanded := make([]string, 0, len(args)+len(words)-1)
+		expanded = append(expanded, args[:pos]...)
+		expanded = append(expanded, words...)
+		expanded = append(expanded, args[pos+1:]...)
+		args = expanded
+	}
+}
+
+// commandIndex returns the position of the first argument that is not an app flag
+// or a flag value, or -1 if there is none.
+func commandIndex(app *cli.App, args []string) int {
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return -1
+		}
+
+		if arg == "-" || !strings.HasPrefix(arg, "-") {
+			return i
+		}
+
+		if strings.Contains(arg, "=") {
+			continue
+		}
+
+		if flagTakesValue(app, strings.TrimLeft(arg, "-")) {
+			i++
+		}
+	}
+
+	return -1
+}
+
+func flagTakesValue(app *cli.App, name string) bool {
+	for _, f := range app.Flags {
+		for _, n := range f.Names() {
+			if n != name {
+				continue
+			}
+
+			if df, ok := f.(cli.DocGenerationFlag); ok {
+				return df.TakesValue()
+			}
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,157 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alias_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/alias"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	appName = "test-tctl-kit"
+)
+
+func TestExpand(t *testing.T) {
+	testcases := map[string]struct {
+		aliases map[string]string
+		input   []string
+		expect  []string
+		err     bool
+	}{
+		"no alias": {
+			input:  []string{"app", "workflow", "list"},
+			expect: []string{"app", "workflow", "list"},
+		},
+		"expands alias": {
+			aliases: map[string]string{"wl": "workflow list --limit 10"},
+			input:   []string{"app", "wl", "--fields", "long"},
+			expect:  []string{"app", "workflow", "list", "--limit", "10", "--fields", "long"},
+		},
+		"skips global flags": {
+			aliases: map[string]string{"wl": "workflow list"},
+			input:   []string{"app", "--address", "wl", "--debug", "wl"},
+			expect:  []string{"app", "--address", "wl", "--debug", "workflow", "list"},
+		},
+		"keeps quoted arguments": {
+			aliases: map[string]string{"running": `workflow list --query 'ExecutionStatus = "Running"'`},
+			input:   []string{"app", "running"},
+			expect:  []string{"app", "workflow", "list", "--query", `ExecutionStatus = "Running"`},
+		},
+		"expands nested aliases": {
+			aliases: map[string]string{"wl": "workflow list", "wl10": "wl --limit 10"},
+			input:   []string{"app", "wl10"},
+			expect:  []string{"app", "workflow", "list", "--limit", "10"},
+		},
+		"does not shadow commands": {
+			aliases: map[string]string{"workflow": "namespace"},
+			input:   []string{"app", "workflow", "list"},
+			expect:  []string{"app", "workflow", "list"},
+		},
+		"detects recursion": {
+			aliases: map[string]string{"aa": "bb --foo", "bb": "aa"},
+			input:   []string{"app", "aa"},
+			err:     true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			cfg, teardown := setupConfig(t)
+			defer teardown()
+
+			cfg.Aliases = tc.aliases
+
+			args, err := alias.Expand(setupApp(cfg), cfg, tc.input)
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expect, args)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	cfg, teardown := setupConfig(t)
+	defer teardown()
+
+	app := setupApp(cfg)
+
+	err := app.Run([]string{"app", "alias", "set", "wl", "workflow", "list", "--query", "a = 'b'"})
+	assert.NoError(t, err)
+	assert.Equal(t, `workflow list --query 'a = '\''b'\'''`, cfg.Alias("wl"))
+
+	err = app.Run([]string{"app", "alias", "set", "lw", "wl --limit 1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "wl --limit 1", cfg.Alias("lw"))
+
+	err = app.Run([]string{"app", "alias", "set", "wl", "lw"})
+	assert.Error(t, err)
+
+	err = app.Run([]string{"app", "alias", "set", "workflow", "list"})
+	assert.Error(t, err)
+
+	reloaded, err := config.NewConfig(appName, strings.TrimSuffix(filepath.Base(cfg.Path()), ".yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.Aliases, reloaded.Aliases)
+
+	err = app.Run([]string{"app", "alias", "remove", "lw"})
+	assert.NoError(t, err)
+	assert.Equal(t, "", cfg.Alias("lw"))
+
+	err = app.Run([]string{"app", "alias", "remove", "lw"})
+	assert.Error(t, err)
+}
+
+func setupApp(cfg *config.Config) *cli.App {
+	app := cli.NewApp()
+	app.Name = "app"
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: "address"},
+		&cli.BoolFlag{Name: "debug"},
+	}
+	app.Commands = []*cli.Command{
+		{Name: "workflow"},
+		alias.NewCommand(cfg),
+	}
+
+	return app
+}
+
+func setupConfig(t *testing.T) (*config.Config, func()) {
+	cfg, err := config.NewConfig(appName, "config-"+uuid.New()[:4])
+	assert.NoError(t, err)
+
+	return cfg, func() {
+		os.Remove(cfg.Path())
+	}
+}
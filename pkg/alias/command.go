@@ -0,0 +1,164 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package alias
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/temporalio/tctl-kit/internal/shellwords"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/temporalio/tctl-kit/pkg/flags"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+)
+
+type aliasRow struct {
+	Name    string
+	Command string
+}
+
+// NewCommand returns the "alias" command with set, get, list and remove subcommands
+// that manage the aliases stored in cfg.
+func NewCommand(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "alias",
+		Usage: "Manage command aliases",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "set",
+				Usage:     "Set a command alias",
+				ArgsUsage: "name command [args...]",
+				Action: func(c *cli.Context) error {
+					return setAlias(c, cfg)
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "Print a command alias",
+				ArgsUsage: "name",
+				Action: func(c *cli.Context) error {
+					return getAlias(c, cfg)
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "List command aliases",
+				Flags: flags.FlagsForRendering,
+				Action: func(c *cli.Context) error {
+					return listAliases(c, cfg)
+				},
+			},
+			{
+				Name:      "remove",
+				Usage:     "Remove a command alias",
+				ArgsUsage: "name",
+				Action: func(c *cli.Context) error {
+					return removeAlias(c, cfg)
+				},
+			},
+		},
+	}
+}
+
+func setAlias(c *cli.Context, cfg *config.Config) error {
+	if c.NArg() < 2 {
+		return fmt.Errorf("alias name and command are required")
+	}
+
+	app := rootApp(c)
+	name := c.Args().First()
+	if app.Command(name) != nil {
+		return fmt.Errorf("alias %v conflicts with an existing command", name)
+	}
+
+	// a single argument is the quoted command line as typed by the user
+	definition := c.Args().Get(1)
+	if c.NArg() > 2 {
+		definition = shellwords.Join(c.Args().Tail())
+	}
+
+	aliases := map[string]string{name: definition}
+	for k, v := range cfg.Aliases {
+		if k != name {
+			aliases[k] = v
+		}
+	}
+	if _, err := expand(app, aliases, []string{app.Name, name}); err != nil {
+		return err
+	}
+
+	return cfg.SetAlias(name, definition)
+}
+
+func getAlias(c *cli.Context, cfg *config.Config) error {
+	name := c.Args().First()
+
+	definition, ok := cfg.Aliases[name]
+	if !ok {
+		return fmt.Errorf("alias not found: %v", name)
+	}
+
+	_, err := fmt.Fprintln(c.App.Writer, definition)
+	return err
+}
+
+func listAliases(c *cli.Context, cfg *config.Config) error {
+	names := make([]string, 0, len(cfg.Aliases))
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var items []interface{}
+	for _, name := range names {
+		items = append(items, &aliasRow{Name: name, Command: cfg.Aliases[name]})
+	}
+
+	opts := &output.PrintOptions{
+		Fields: []string{"Name", "Command"},
+	}
+	return output.PrintItems(c, items, opts)
+}
+
+func removeAlias(c *cli.Context, cfg *config.Config) error {
+	name := c.Args().First()
+
+	if _, ok := cfg.Aliases[name]; !ok {
+		return fmt.Errorf("alias not found: %v", name)
+	}
+
+	return cfg.RemoveAlias(name)
+}
+
+// rootApp returns the top level app, as c.App of a subcommand is a nested app.
+func rootApp(c *cli.Context) *cli.App {
+	app := c.App
+	for _, ctx := range c.Lineage() {
+		if ctx.App != nil {
+			app = ctx.App
+		}
+	}
+
+	return app
+}
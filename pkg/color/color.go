@@ -25,7 +25,12 @@
 package color
 
 import (
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/urfave/cli/v2"
 )
 
@@ -41,6 +46,13 @@ const (
 	Never  ColorOption = "never"
 )
 
+// Flag is the flag to choose when to print colors. Add it to the flags of the app or command
+var Flag = &cli.StringFlag{
+	Name:  FlagColor,
+	Usage: fmt.Sprintf("when to print colors: %v, %v, %v. Also follows NO_COLOR, CLICOLOR and CLICOLOR_FORCE env variables", Auto, Always, Never),
+	Value: string(Auto),
+}
+
 var (
 	colorGreen   = color.New(color.FgGreen).SprintfFunc()
 	colorMagenta = color.New(color.FgMagenta).SprintfFunc()
@@ -67,18 +79,76 @@ func Red(c *cli.Context, format string, a ...interface{}) string {
 	return colorRed(format, a...)
 }
 
-func checkColor(c *cli.Context) {
-	colorFlag := c.String(FlagColor)
+// Sprintf formats the text in a color or style by name, ex. "green" or "bold hi-red", see ParseStyle.
+// Unlike Green, Magenta.. it doesn't depend on the global color setting, the text is only colored if enabled.
+func Sprintf(enabled bool, name string, format string, a ...interface{}) (string, error) {
+	style, err := ParseStyle(name)
+	if err != nil {
+		return "", err
+	}
+
+	return style.Sprintf(enabled, format, a...), nil
+}
+
+// Option returns when to print colors. The --color flag takes precedence over the env variables:
+// NO_COLOR disables colors, CLICOLOR_FORCE enables them and CLICOLOR=0 disables them.
+func Option(c *cli.Context) ColorOption {
+	if c.IsSet(FlagColor) {
+		return parseOption(c.String(FlagColor))
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return Never
+	}
+
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return Always
+	}
 
-	colorVal := ColorOption(colorFlag)
-	switch colorVal {
+	if os.Getenv("CLICOLOR") == "0" {
+		return Never
+	}
+
+	// the default value of the flag
+	return parseOption(c.String(FlagColor))
+}
+
+func parseOption(value string) ColorOption {
+	switch option := ColorOption(value); option {
+	case Always, Never:
+		return option
+	default:
+		return Auto
+	}
+}
+
+// Enabled reports whether to print colors to the writer.
+// With the auto option, colors are printed only if the writer is a terminal.
+func Enabled(c *cli.Context, w io.Writer) bool {
+	switch Option(c) {
 	case Always:
-		color.NoColor = false
+		return true
 	case Never:
-		color.NoColor = true
+		return false
+	default:
+		return IsTerminal(w) && os.Getenv("TERM") != "dumb"
+	}
+}
 
-	default: // auto
-		// handled by color pkg
-		// tty - color. non-tty - false
+// IsTerminal reports whether the writer is a terminal.
+// Writers that aren't files, such as pagers, can tell it with an IsTerminal method.
+func IsTerminal(w io.Writer) bool {
+	switch w := w.(type) {
+	case interface{ IsTerminal() bool }:
+		return w.IsTerminal()
+	case interface{ Fd() uintptr }:
+		return isatty.IsTerminal(w.Fd()) || isatty.IsCygwinTerminal(w.Fd())
+	default:
+		return false
 	}
 }
+
+// checkColor sets the global color setting used by Green, Magenta.. for the app writer
+func checkColor(c *cli.Context) {
+	color.NoColor = !Enabled(c, c.App.Writer)
+}
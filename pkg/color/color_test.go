@@ -0,0 +1,113 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package color_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/urfave/cli/v2"
+)
+
+func setupColorTest(args ...string) *cli.Context {
+	app := cli.NewApp()
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.String(color.FlagColor, string(color.Auto), "")
+	flagSet.String(color.FlagTheme, "", "")
+	flagSet.Parse(args)
+
+	return cli.NewContext(app, flagSet, nil)
+}
+
+func TestOption(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		env  map[string]string
+		want color.ColorOption
+	}{
+		"default":                      {want: color.Auto},
+		"flag":                         {args: []string{"--color", "never"}, want: color.Never},
+		"invalid flag":                 {args: []string{"--color", "sometimes"}, want: color.Auto},
+		"NO_COLOR":                     {env: map[string]string{"NO_COLOR": "1"}, want: color.Never},
+		"empty NO_COLOR":               {env: map[string]string{"NO_COLOR": ""}, want: color.Auto},
+		"flag over NO_COLOR":           {args: []string{"--color", "always"}, env: map[string]string{"NO_COLOR": "1"}, want: color.Always},
+		"CLICOLOR_FORCE":               {env: map[string]string{"CLICOLOR_FORCE": "1"}, want: color.Always},
+		"CLICOLOR_FORCE=0":             {env: map[string]string{"CLICOLOR_FORCE": "0"}, want: color.Auto},
+		"NO_COLOR over CLICOLOR_FORCE": {env: map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, want: color.Never},
+		"CLICOLOR=0":                   {env: map[string]string{"CLICOLOR": "0"}, want: color.Never},
+		"CLICOLOR=1":                   {env: map[string]string{"CLICOLOR": "1"}, want: color.Auto},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"NO_COLOR", "CLICOLOR_FORCE", "CLICOLOR"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			assert.Equal(t, tt.want, color.Option(setupColorTest(tt.args...)))
+		})
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	t.Setenv("CLICOLOR", "")
+
+	var buf bytes.Buffer
+	assert.False(t, color.Enabled(setupColorTest(), &buf))
+	assert.True(t, color.Enabled(setupColorTest("--color", "always"), &buf))
+	assert.True(t, color.Enabled(setupColorTest(), &terminalWriter{}))
+	assert.False(t, color.Enabled(setupColorTest("--color", "never"), &terminalWriter{}))
+
+	// a file that isn't a terminal
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.False(t, color.Enabled(setupColorTest(), f))
+}
+
+type terminalWriter struct {
+	bytes.Buffer
+}
+
+func (terminalWriter) IsTerminal() bool {
+	return true
+}
+
+func TestSprintf(t *testing.T) {
+	text, err := color.Sprintf(true, "green", "%v", "ok")
+	assert.NoError(t, err)
+	assert.Equal(t, "\x1b[32mok\x1b[0m", text)
+
+	text, err = color.Sprintf(false, "green", "%v", "ok")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", text)
+
+	_, err = color.Sprintf(true, "purple", "%v", "ok")
+	assert.EqualError(t, err, "unknown color purple")
+}
@@ -0,0 +1,260 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package color
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	FlagTheme = "theme"
+)
+
+// Role is the meaning of a printed value, colored by the theme
+type Role string
+
+const (
+	RoleHeader  Role = "header"
+	RoleKey     Role = "key"
+	RoleSuccess Role = "success"
+	RoleWarning Role = "warning"
+	RoleError   Role = "error"
+	RoleMuted   Role = "muted"
+	RoleStatus  Role = "status"
+)
+
+// Roles are all the roles a theme colors
+var Roles = []Role{RoleHeader, RoleKey, RoleSuccess, RoleWarning, RoleError, RoleMuted, RoleStatus}
+
+const (
+	DefaultTheme      = "default"
+	HighContrastTheme = "high-contrast"
+	// ColorblindTheme avoids telling values apart by red and green
+	ColorblindTheme = "colorblind"
+)
+
+// ThemeFlag is the flag to select a theme. Add it to the flags of the app or command
+var ThemeFlag = &cli.StringFlag{
+	Name:  FlagTheme,
+	Usage: fmt.Sprintf("color theme: %v, %v, %v or a theme from the config file", DefaultTheme, HighContrastTheme, ColorblindTheme),
+}
+
+// metadataThemes is the key of the configured themes in the app metadata
+const metadataThemes = "color.themes"
+
+// Style is a set of colors and attributes, ex. bold hi-magenta
+type Style []color.Attribute
+
+// Theme is the style of each role
+type Theme map[Role]Style
+
+var themes = map[string]Theme{
+	DefaultTheme: {
+		RoleHeader:  {color.FgHiMagenta},
+		RoleKey:     {color.FgCyan},
+		RoleSuccess: {color.FgGreen},
+		RoleWarning: {color.FgYellow},
+		RoleError:   {color.FgRed},
+		RoleMuted:   {color.FgHiBlack},
+		RoleStatus:  {color.FgBlue},
+	},
+	HighContrastTheme: {
+		RoleHeader:  {color.Bold, color.Underline, color.FgHiWhite},
+		RoleKey:     {color.Bold, color.FgHiWhite},
+		RoleSuccess: {color.Bold, color.FgHiGreen},
+		RoleWarning: {color.Bold, color.FgHiYellow},
+		RoleError:   {color.Bold, color.FgHiRed},
+		RoleMuted:   {color.FgWhite},
+		RoleStatus:  {color.Bold, color.FgHiCyan},
+	},
+	ColorblindTheme: {
+		RoleHeader:  {color.Bold, color.FgHiMagenta},
+		RoleKey:     {color.FgCyan},
+		RoleSuccess: {color.FgHiBlue},
+		RoleWarning: {color.FgYellow},
+		RoleError:   {color.Bold, color.FgHiMagenta, color.Underline},
+		RoleMuted:   {color.FgHiBlack},
+		RoleStatus:  {color.FgHiCyan},
+	},
+}
+
+// styleNames are the names of the colors and attributes used in styles
+var styleNames = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+	"reverse":   color.ReverseVideo,
+	"gray":      color.FgHiBlack,
+}
+
+func init() {
+	colors := []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+	for i, name := range colors {
+		styleNames[name] = color.FgBlack + color.Attribute(i)
+		styleNames["hi-"+name] = color.FgHiBlack + color.Attribute(i)
+		styleNames["bg-"+name] = color.BgBlack + color.Attribute(i)
+		styleNames["bg-hi-"+name] = color.BgHiBlack + color.Attribute(i)
+	}
+}
+
+// ParseStyle parses space separated colors and attributes, ex. "bold hi-magenta" or "bg-red white"
+func ParseStyle(spec string) (Style, error) {
+	var style Style
+	for _, name := range strings.Fields(spec) {
+		attr, ok := styleNames[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown color %v", name)
+		}
+		style = append(style, attr)
+	}
+
+	return style, nil
+}
+
+// Sprintf formats the text in the style, if enabled
+func (s Style) Sprintf(enabled bool, format string, a ...interface{}) string {
+	if !enabled || len(s) == 0 {
+		return fmt.Sprintf(format, a...)
+	}
+
+	col := color.New(s...)
+	col.EnableColor()
+	return col.Sprintf(format, a...)
+}
+
+// Ints returns the attributes as integers, as used by tablewriter.Colors
+func (s Style) Ints() []int {
+	ints := make([]int, len(s))
+	for i, attr := range s {
+		ints[i] = int(attr)
+	}
+	return ints
+}
+
+// Sprintf formats the text in the style of the role, if enabled
+func (t Theme) Sprintf(enabled bool, role Role, format string, a ...interface{}) string {
+	return t[role].Sprintf(enabled, format, a...)
+}
+
+type configuredThemes struct {
+	selected string
+	themes   map[string]Theme
+}
+
+// ConfigureThemesFromConfig adds the themes of the config file, from the theme and themes keys, to the app
+func ConfigureThemesFromConfig(app *cli.App, cfg *config.Config) error {
+	return ConfigureThemes(app, cfg.Theme, cfg.Themes)
+}
+
+// ConfigureThemes adds the themes from the config file to the app and selects the theme to use
+// when the theme flag isn't set. Themes map the roles to styles, ex. header: "bold hi-magenta".
+// Roles not set by a theme are taken from the default theme.
+func ConfigureThemes(app *cli.App, selected string, specs map[string]map[string]string) error {
+	configured := &configuredThemes{selected: selected, themes: map[string]Theme{}}
+
+	for name, roles := range specs {
+		theme := Theme{}
+		for role, style := range themes[DefaultTheme] {
+			theme[role] = style
+		}
+
+		for role, spec := range roles {
+			if !isRole(Role(role)) {
+				return fmt.Errorf("invalid theme %v: unknown role %v", name, role)
+			}
+
+			style, err := ParseStyle(spec)
+			if err != nil {
+				return fmt.Errorf("invalid theme %v: %w", name, err)
+			}
+			theme[Role(role)] = style
+		}
+
+		configured.themes[name] = theme
+	}
+
+	if app.Metadata == nil {
+		app.Metadata = map[string]interface{}{}
+	}
+	app.Metadata[metadataThemes] = configured
+
+	return nil
+}
+
+// CurrentTheme returns the theme selected with the theme flag or in the config file, or the default theme
+func CurrentTheme(c *cli.Context) (Theme, error) {
+	configured, _ := c.App.Metadata[metadataThemes].(*configuredThemes)
+
+	name := c.String(FlagTheme)
+	if name == "" && configured != nil {
+		name = configured.selected
+	}
+	if name == "" {
+		name = DefaultTheme
+	}
+
+	if configured != nil {
+		if theme, ok := configured.themes[name]; ok {
+			return theme, nil
+		}
+	}
+
+	if theme, ok := themes[name]; ok {
+		return theme, nil
+	}
+
+	return nil, fmt.Errorf("unknown theme %v, available themes: %v", name, strings.Join(themeNames(configured), ", "))
+}
+
+func themeNames(configured *configuredThemes) []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	if configured != nil {
+		for name := range configured.themes {
+			if _, ok := themes[name]; !ok {
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func isRole(role Role) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
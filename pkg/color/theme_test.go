@@ -0,0 +1,125 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package color_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	tcolor "github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+func TestParseStyle(t *testing.T) {
+	style, err := tcolor.ParseStyle("bold hi-magenta bg-Blue")
+	assert.NoError(t, err)
+	assert.Equal(t, tcolor.Style{color.Bold, color.FgHiMagenta, color.BgBlue}, style)
+
+	_, err = tcolor.ParseStyle("bold purple")
+	assert.EqualError(t, err, "unknown color purple")
+}
+
+func TestStyle_Sprintf(t *testing.T) {
+	style := tcolor.Style{color.Bold, color.FgRed}
+
+	assert.Equal(t, "\x1b[1;31mfailed\x1b[0m", style.Sprintf(true, "%v", "failed"))
+	assert.Equal(t, "failed", style.Sprintf(false, "%v", "failed"))
+	assert.Equal(t, "failed", tcolor.Style{}.Sprintf(true, "%v", "failed"))
+}
+
+func TestCurrentTheme(t *testing.T) {
+	themes := map[string]map[string]string{
+		"custom":  {"header": "bold cyan"},
+		"invalid": {},
+	}
+
+	tests := map[string]struct {
+		args     []string
+		selected string
+		role     tcolor.Role
+		want     tcolor.Style
+		wantErr  string
+	}{
+		"default":                    {role: tcolor.RoleHeader, want: tcolor.Style{color.FgHiMagenta}},
+		"flag":                       {args: []string{"--theme", "high-contrast"}, role: tcolor.RoleError, want: tcolor.Style{color.Bold, color.FgHiRed}},
+		"selected in config":         {selected: "colorblind", role: tcolor.RoleSuccess, want: tcolor.Style{color.FgHiBlue}},
+		"flag over config":           {args: []string{"--theme", "default"}, selected: "colorblind", role: tcolor.RoleSuccess, want: tcolor.Style{color.FgGreen}},
+		"custom theme":               {selected: "custom", role: tcolor.RoleHeader, want: tcolor.Style{color.Bold, color.FgCyan}},
+		"custom theme default roles": {selected: "custom", role: tcolor.RoleError, want: tcolor.Style{color.FgRed}},
+		"unknown theme": {
+			args:    []string{"--theme", "dark"},
+			wantErr: "unknown theme dark, available themes: colorblind, custom, default, high-contrast, invalid",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := setupColorTest(tt.args...)
+			assert.NoError(t, tcolor.ConfigureThemes(c.App, tt.selected, themes))
+
+			theme, err := tcolor.CurrentTheme(c)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, theme[tt.role])
+		})
+	}
+}
+
+func TestConfigureThemes_Invalid(t *testing.T) {
+	app := cli.NewApp()
+
+	err := tcolor.ConfigureThemes(app, "", map[string]map[string]string{"custom": {"title": "bold"}})
+	assert.EqualError(t, err, "invalid theme custom: unknown role title")
+
+	err = tcolor.ConfigureThemes(app, "", map[string]map[string]string{"custom": {"header": "purple"}})
+	assert.EqualError(t, err, "invalid theme custom: unknown color purple")
+}
+
+func TestConfigureThemesFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte("theme: custom\nthemes:\n  custom:\n    header: bold cyan\n"), 0600)
+	assert.NoError(t, err)
+
+	cfg, err := config.NewConfig("test-tctl-kit", "config", config.WithPath(path))
+	assert.NoError(t, err)
+
+	app := cli.NewApp()
+	assert.NoError(t, tcolor.ConfigureThemesFromConfig(app, cfg))
+
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.String(tcolor.FlagTheme, "", "")
+	c := cli.NewContext(app, flagSet, nil)
+
+	theme, err := tcolor.CurrentTheme(c)
+	assert.NoError(t, err)
+	assert.Equal(t, tcolor.Style{color.Bold, color.FgCyan}, theme[tcolor.RoleHeader])
+}
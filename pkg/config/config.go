@@ -35,25 +35,55 @@ import (
 const DefaultEnv = "default"
 
 type Config struct {
-	Envs map[string]map[string]string `yaml:"env"`
+	Envs    map[string]map[string]string `yaml:"env"`
+	Aliases map[string]string            `yaml:"alias,omitempty"`
+	Pager   *PagerConfig                 `yaml:"pager,omitempty"`
+	Theme   string                       `yaml:"theme,omitempty"`
+	Themes  map[string]map[string]string `yaml:"themes,omitempty"`
+
+	appName  string
+	dir      string
+	file     string
+	readOnly bool
+}
 
-	dir  string
-	file string
+// PagerConfig configures the pager used to print the output
+type PagerConfig struct {
+	// Command is the pager command with arguments, ex. "less -S"
+	Command string `yaml:"command,omitempty"`
+	// Env are the environment variables of the pager, ex. LESS: FRSX
+	Env map[string]string `yaml:"env,omitempty"`
 }
 
 func (c *Config) Path() string {
 	return filepath.Join(c.dir, c.file)
 }
 
-func NewConfig(appName, configName string) (*Config, error) {
-	dir, file, err := configPath(appName, configName)
+// NewConfig reads the config file configName.yaml of the app. The file is looked up in $XDG_CONFIG_HOME/<app>,
+// falling back to $HOME/.config/<app>, unless a path is set with WithPath or the <APP>_CONFIG env variable.
+// A missing file is created on the first write
+func NewConfig(appName, configName string, opts ...Option) (*Config, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
 
+	dir, file, explicit, err := configPath(appName, configName, o)
 	if err != nil {
 		return nil, err
 	}
 
 	cfgPath := filepath.Join(dir, file)
 
+	// an explicit path is used as is
+	if o.legacyPath != "" && !explicit {
+		// a read-only config is read from the legacy path instead of being moved
+		if cfgPath, err = migrate(o.legacyPath, cfgPath, o.readOnly); err != nil {
+			return nil, err
+		}
+		dir, file = filepath.Dir(cfgPath), filepath.Base(cfgPath)
+	}
+
 	cfg, err := readConfig(cfgPath)
 	if errors.Is(err, os.ErrNotExist) {
 		cfg = &Config{}
@@ -65,12 +95,19 @@ func NewConfig(appName, configName string) (*Config, error) {
 		cfg.Envs = map[string]map[string]string{DefaultEnv: {}}
 	}
 
+	cfg.appName = appName
 	cfg.dir = dir
 	cfg.file = file
+	cfg.readOnly = o.readOnly
 
 	return cfg, nil
 }
 
+// ReadOnly reports whether the config file is never written
+func (c *Config) ReadOnly() bool {
+	return c.readOnly
+}
+
 func (c *Config) Env(name string) map[string]string {
 	return c.Envs[name]
 }
@@ -121,6 +158,34 @@ func (c *Config) RemoveEnvProperty(envName, key string) error {
 	return nil
 }
 
+func (c *Config) Alias(name string) string {
+	return c.Aliases[name]
+}
+
+func (c *Config) SetAlias(name, command string) error {
+	if err := validateKey(name); err != nil {
+		return fmt.Errorf("invalid alias name: %w", err)
+	}
+
+	if c.Aliases == nil {
+		c.Aliases = map[string]string{}
+	}
+
+	c.Aliases[name] = command
+
+	return c.writeFile()
+}
+
+func (c *Config) RemoveAlias(name string) error {
+	if _, ok := c.Aliases[name]; ok {
+		delete(c.Aliases, name)
+
+		return c.writeFile()
+	}
+
+	return nil
+}
+
 func mkfile(dir, file string) (string, error) {
 	if err := mkdir(dir); err != nil {
 		return "", err
@@ -169,6 +234,10 @@ func readConfig(path string) (*Config, error) {
 }
 
 func (c *Config) writeFile() error {
+	if c.readOnly {
+		return fmt.Errorf("unable to write config file %v: %w", c.Path(), ErrReadOnly)
+	}
+
 	fPath, err := mkfile(c.dir, c.file)
 	if err != nil {
 		return err
@@ -219,14 +288,35 @@ func validateKey(key string) error {
 	return nil
 }
 
-func configPath(appName, configName string) (string, string, error) {
-	dir, err := os.UserHomeDir()
-	if err != nil {
-		return "", "", err
+// configPath returns the directory and the name of the config file,
+// and whether the path is set explicitly with WithPath or the <APP>_CONFIG env variable
+func configPath(appName, configName string, o *options) (string, string, bool, error) {
+	path := o.path
+	if path == "" {
+		path = os.Getenv(PathEnvVar(appName))
+	}
+	if path != "" {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return "", "", false, fmt.Errorf("unable to resolve config path: %w", err)
+		}
+		return filepath.Dir(path), filepath.Base(path), true, nil
+	}
+
+	dir := o.dir
+	if dir == "" {
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" && filepath.IsAbs(xdg) {
+			dir = filepath.Join(xdg, appName)
+		} else {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", "", false, err
+			}
+			dir = filepath.Join(home, ".config", appName)
+		}
 	}
 
-	dir = filepath.Join(dir, ".config", appName)
 	file := configName + ".yaml"
 
-	return dir, file, nil
+	return dir, file, false, nil
 }
@@ -26,6 +26,7 @@ import "os"
 
 const (
 	KeyEnvironment string = "env"
+	FlagConfig     string = "config"
 )
 
 const (
@@ -0,0 +1,210 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// EnvFlag selects the env of the config file to read the flag values from, ex. --env prod
+var EnvFlag = &cli.StringFlag{
+	Name:  KeyEnvironment,
+	Usage: "Name of the config env to read the unset flag values from",
+	Value: DefaultEnv,
+}
+
+// ConfigureEnv fills in the flags of the app and its commands from the env selected by --env.
+// It adds EnvFlag to the app if it's missing and completes --env with the env names
+func ConfigureEnv(app *cli.App, cfg *Config) {
+	configureEnv(app, staticConfig(cfg))
+}
+
+// loadFunc returns the config used to fill in the flags
+type loadFunc func(c *cli.Context) (*Config, error)
+
+func staticConfig(cfg *Config) loadFunc {
+	return func(c *cli.Context) (*Config, error) {
+		return cfg, nil
+	}
+}
+
+func configureEnv(app *cli.App, load loadFunc) {
+	if !hasFlag(app.Flags, KeyEnvironment) {
+		app.Flags = append(app.Flags, EnvFlag)
+	}
+
+	app.Before = chainBefore(envBefore(load), app.Before)
+	app.BashComplete = completeEnvs(load, app.BashComplete, cli.DefaultAppComplete)
+
+	configureCommands(app.Commands, load)
+}
+
+func configureCommands(commands []*cli.Command, load loadFunc) {
+	for _, cmd := range commands {
+		cmd.Before = chainBefore(envBefore(load), cmd.Before)
+
+		// commands with subcommands run as apps
+		fallback := cli.DefaultAppComplete
+		if len(cmd.Subcommands) == 0 {
+			fallback = cli.DefaultCompleteWithFlags(cmd)
+		}
+		cmd.BashComplete = completeEnvs(load, cmd.BashComplete, fallback)
+
+		configureCommands(cmd.Subcommands, load)
+	}
+}
+
+// EnvBeforeFunc returns a cli.BeforeFunc that sets the flags of the command, that are not set explicitly,
+// to the properties with the same name. Properties are resolved in the env selected by --env, see ResolveProperty
+func EnvBeforeFunc(cfg *Config) cli.BeforeFunc {
+	return envBefore(staticConfig(cfg))
+}
+
+func envBefore(load loadFunc) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		cfg, err := load(c)
+		if err != nil {
+			return err
+		}
+
+		env := c.String(KeyEnvironment)
+		if env == "" {
+			env = DefaultEnv
+		}
+
+		if _, ok := cfg.Envs[env]; !ok && env != DefaultEnv && !cfg.hasEnvVars(env) {
+			return fmt.Errorf("env not found: %v", env)
+		}
+
+		for _, flag := range localFlags(c) {
+			names := flag.Names()
+			if names[0] == KeyEnvironment || isSet(c, names) {
+				continue
+			}
+
+			for _, name := range names {
+				value, ok, err := cfg.ResolveProperty(env, name, nil)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+
+				if err := c.Set(name, value.Value); err != nil {
+					return fmt.Errorf("unable to set flag %v from env %v: %w", name, env, err)
+				}
+				break
+			}
+		}
+
+		return nil
+	}
+}
+
+// localFlags returns the flags parsed in the context of a command or an app
+func localFlags(c *cli.Context) []cli.Flag {
+	if c.Command != nil && c.Command.Name != "" {
+		return c.Command.Flags
+	}
+	return c.App.Flags
+}
+
+func isSet(c *cli.Context, names []string) bool {
+	for _, name := range names {
+		if c.IsSet(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasFlag(flags []cli.Flag, name string) bool {
+	for _, flag := range flags {
+		for _, n := range flag.Names() {
+			if n == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func chainBefore(first, next cli.BeforeFunc) cli.BeforeFunc {
+	if next == nil {
+		return first
+	}
+	return func(c *cli.Context) error {
+		if err := first(c); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// completeEnvs completes the value of --env with the env names, other completions are passed to complete or fallback
+func completeEnvs(load loadFunc, complete, fallback cli.BashCompleteFunc) cli.BashCompleteFunc {
+	if complete == nil {
+		complete = fallback
+	}
+
+	return func(c *cli.Context) {
+		// the last argument is the completion flag, ex. "app --env --generate-bash-completion"
+		if len(os.Args) > 2 && strings.TrimLeft(os.Args[len(os.Args)-2], "-") == KeyEnvironment {
+			// an unreadable config has no envs to complete
+			if cfg, err := load(c); err == nil {
+				for _, env := range cfg.EnvNames() {
+					fmt.Fprintln(c.App.Writer, env)
+				}
+			}
+			return
+		}
+
+		complete(c)
+	}
+}
+
+// EnvNames returns the sorted names of the envs in the config file
+func (c *Config) EnvNames() []string {
+	names := make([]string, 0, len(c.Envs))
+	for name := range c.Envs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// hasEnvVars reports whether any property of env is set by an environment variable
+func (c *Config) hasEnvVars(env string) bool {
+	prefix := envVarPrefix(c.appName, env)
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, prefix) {
+			return true
+		}
+	}
+	return false
+}
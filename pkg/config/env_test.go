@@ -0,0 +1,155 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+const envConfig = `env:
+  default:
+    address: localhost:7233
+    namespace: default
+  prod:
+    address: prod:7233
+    limit: "20"`
+
+type envFlags struct {
+	address, namespace string
+	limit              int
+}
+
+func newEnvApp(cfg *config.Config, got *envFlags) *cli.App {
+	app := cli.NewApp()
+	app.Name = "app"
+	app.Flags = []cli.Flag{&cli.StringFlag{Name: "address"}}
+	app.Commands = []*cli.Command{
+		{
+			Name: "workflow",
+			Subcommands: []*cli.Command{
+				{
+					Name: "list",
+					Flags: []cli.Flag{
+						&cli.StringFlag{Name: "namespace", Aliases: []string{"n"}},
+						&cli.IntFlag{Name: "limit"},
+					},
+					Action: func(c *cli.Context) error {
+						*got = envFlags{c.String("address"), c.String("namespace"), c.Int("limit")}
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	config.ConfigureEnv(app, cfg)
+	return app
+}
+
+func TestConfigureEnv(t *testing.T) {
+	testcases := map[string]struct {
+		args      []string
+		envVars   map[string]string
+		expect    envFlags
+		expectErr bool
+	}{
+		"fills flags from default env": {
+			args:   []string{"workflow", "list"},
+			expect: envFlags{"localhost:7233", "default", 0},
+		},
+		"fills flags from selected env": {
+			args:   []string{"--env", "prod", "workflow", "list"},
+			expect: envFlags{"prod:7233", "default", 20},
+		},
+		"keeps flags set explicitly": {
+			args:   []string{"--env", "prod", "--address", "flag:7233", "workflow", "list", "-n", "orders", "--limit", "5"},
+			expect: envFlags{"flag:7233", "orders", 5},
+		},
+		"fills flags from environment variables": {
+			args:    []string{"--env", "prod", "workflow", "list"},
+			envVars: map[string]string{"TEST_TCTL_KIT_ENV_PROD_NAMESPACE": "payments"},
+			expect:  envFlags{"prod:7233", "payments", 20},
+		},
+		"env set by environment variables only": {
+			args:    []string{"--env", "ci", "workflow", "list"},
+			envVars: map[string]string{"TEST_TCTL_KIT_ENV_CI_ADDRESS": "ci:7233"},
+			expect:  envFlags{"ci:7233", "default", 0},
+		},
+		"throws on unknown env": {
+			args:      []string{"--env", "staging", "workflow", "list"},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			cfg, teardown := setupConfig(t, envConfig)
+			defer teardown()
+
+			for k, v := range tc.envVars {
+				t.Setenv(k, v)
+			}
+
+			var got envFlags
+			err := newEnvApp(cfg, &got).Run(append([]string{"app"}, tc.args...))
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestConfigureEnv_Completion(t *testing.T) {
+	testcases := map[string][]string{
+		"app":               {"app", "--env", "--generate-bash-completion"},
+		"nested subcommand": {"app", "workflow", "list", "--env", "--generate-bash-completion"},
+	}
+
+	for name, args := range testcases {
+		t.Run(name, func(t *testing.T) {
+			cfg, teardown := setupConfig(t, envConfig)
+			defer teardown()
+
+			osArgs := os.Args
+			os.Args = args
+			defer func() { os.Args = osArgs }()
+
+			var buf bytes.Buffer
+			app := newEnvApp(cfg, &envFlags{})
+			app.EnableBashCompletion = true
+			app.Writer = &buf
+
+			err := app.Run(args)
+			assert.NoError(t, err)
+			assert.Equal(t, "default\nprod\n", buf.String())
+		})
+	}
+}
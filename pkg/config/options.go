@@ -0,0 +1,176 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+)
+
+const metadataConfig = "config.config"
+
+// ErrReadOnly is returned when changing a config opened with WithReadOnly
+var ErrReadOnly = errors.New("config is read-only")
+
+// Option configures how NewConfig finds the config file
+type Option func(*options)
+
+type options struct {
+	dir        string
+	path       string
+	readOnly   bool
+	legacyPath string
+}
+
+// WithDir sets the directory of the config file, instead of $XDG_CONFIG_HOME/<app> or $HOME/.config/<app>
+func WithDir(dir string) Option {
+	return func(o *options) {
+		o.dir = dir
+	}
+}
+
+// WithPath sets the path of the config file, ex. from the --config flag. An empty path is ignored
+func WithPath(path string) Option {
+	return func(o *options) {
+		o.path = path
+	}
+}
+
+// WithReadOnly never writes the config file, ex. when it's mounted in an unwritable location.
+// Changes to the config return ErrReadOnly
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) {
+		o.readOnly = readOnly
+	}
+}
+
+// WithLegacyPath moves the config file from the legacy path, used by the previous versions of the app,
+// when the config file doesn't exist yet. It's not applied to the path set with WithPath or <APP>_CONFIG
+func WithLegacyPath(path string) Option {
+	return func(o *options) {
+		o.legacyPath = path
+	}
+}
+
+// PathEnvVar returns the env variable with the path of the config file, ex. TEMPORAL_CONFIG
+func PathEnvVar(appName string) string {
+	return envVarWord(appName) + "_CONFIG"
+}
+
+// PathFlag returns the --config flag with the path of the config file, also read from PathEnvVar
+func PathFlag(appName string) *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:    FlagConfig,
+		Usage:   "Path of the config file",
+		EnvVars: []string{PathEnvVar(appName)},
+	}
+}
+
+// ConfigureApp adds PathFlag to the app and loads the config file once the flags are parsed, from the path in
+// --config or as NewConfig without it. The unset flags are filled in from the env selected by --env, as in
+// ConfigureEnv. It's called after setting the Before funcs of the app, which can get the config with FromContext
+func ConfigureApp(app *cli.App, appName, configName string, opts ...Option) {
+	if !hasFlag(app.Flags, FlagConfig) {
+		app.Flags = append(app.Flags, PathFlag(appName))
+	}
+
+	configureEnv(app, func(c *cli.Context) (*Config, error) {
+		if cfg := FromContext(c); cfg != nil {
+			return cfg, nil
+		}
+
+		cfgOpts := append([]Option{}, opts...)
+		if path := c.String(FlagConfig); path != "" {
+			cfgOpts = append(cfgOpts, WithPath(path))
+		}
+		cfg, err := NewConfig(appName, configName, cfgOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load config: %w", err)
+		}
+
+		// the metadata is shared with the subcommands
+		if c.App.Metadata == nil {
+			c.App.Metadata = map[string]interface{}{}
+		}
+		c.App.Metadata[metadataConfig] = cfg
+
+		return cfg, nil
+	})
+}
+
+// FromContext returns the config loaded by ConfigureApp, or nil if it's not loaded
+func FromContext(c *cli.Context) *Config {
+	cfg, _ := c.App.Metadata[metadataConfig].(*Config)
+	return cfg
+}
+
+// migrate moves the legacy config file to path, unless path exists. It returns the path to read the config from
+func migrate(legacyPath, path string, readOnly bool) (string, error) {
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	}
+
+	if _, err := os.Stat(legacyPath); errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if readOnly {
+		return legacyPath, nil
+	}
+
+	if err := mkdir(filepath.Dir(path)); err != nil {
+		return "", fmt.Errorf("unable to migrate config file %v: %w", legacyPath, err)
+	}
+
+	// the file is moved as is, keeping the comments and the keys unknown to Config
+	if err := os.Rename(legacyPath, path); err != nil {
+		// rename fails across devices, the file is copied instead
+		if err := copyFile(legacyPath, path); err != nil {
+			return "", fmt.Errorf("unable to migrate config file %v: %w", legacyPath, err)
+		}
+
+		if err := os.Remove(legacyPath); err != nil {
+			return "", fmt.Errorf("unable to remove legacy config file %v: %w", legacyPath, err)
+		}
+	}
+
+	if err := os.Chmod(path, ownerReadWrite); err != nil {
+		return "", fmt.Errorf("unable to update config file permission to %s: %w", ownerReadWrite, err)
+	}
+
+	return path, nil
+}
+
+func copyFile(src, dst string) error {
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dst, content, ownerReadWrite)
+}
@@ -0,0 +1,300 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNewConfigPath(t *testing.T) {
+	xdg := t.TempDir()
+	dir := t.TempDir()
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+
+	home, err := os.UserHomeDir()
+	assert.NoError(t, err)
+
+	testcases := map[string]struct {
+		xdg     string
+		envPath string
+		opts    []config.Option
+		expect  string
+	}{
+		"home config dir": {
+			expect: filepath.Join(home, ".config", appName, "test.yaml"),
+		},
+		"XDG_CONFIG_HOME": {
+			xdg:    xdg,
+			expect: filepath.Join(xdg, appName, "test.yaml"),
+		},
+		"relative XDG_CONFIG_HOME is ignored": {
+			xdg:    "relative",
+			expect: filepath.Join(home, ".config", appName, "test.yaml"),
+		},
+		"dir option": {
+			xdg:    xdg,
+			opts:   []config.Option{config.WithDir(dir)},
+			expect: filepath.Join(dir, "test.yaml"),
+		},
+		"path env var": {
+			xdg:     xdg,
+			envPath: path,
+			expect:  path,
+		},
+		"path option": {
+			envPath: filepath.Join(dir, "other.yaml"),
+			opts:    []config.Option{config.WithDir(dir), config.WithPath(path)},
+			expect:  path,
+		},
+		"empty path option": {
+			opts:   []config.Option{config.WithDir(dir), config.WithPath("")},
+			expect: filepath.Join(dir, "test.yaml"),
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", tc.xdg)
+			t.Setenv(config.PathEnvVar(appName), tc.envPath)
+
+			cfg, err := config.NewConfig(appName, "test", tc.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, cfg.Path())
+		})
+	}
+}
+
+func TestPathEnvVar(t *testing.T) {
+	assert.Equal(t, "TEST_TCTL_KIT_CONFIG", config.PathEnvVar(appName))
+	assert.Equal(t, []string{"TEST_TCTL_KIT_CONFIG"}, config.PathFlag(appName).EnvVars)
+}
+
+func TestNewConfigReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+	writeConfig(t, path, "env:\n  default:\n    address: ci:7233\n")
+
+	cfg, err := config.NewConfig(appName, "test", config.WithPath(path), config.WithReadOnly(true))
+	assert.NoError(t, err)
+	assert.True(t, cfg.ReadOnly())
+
+	value, err := cfg.EnvProperty(config.DefaultEnv, "address")
+	assert.NoError(t, err)
+	assert.Equal(t, "ci:7233", value)
+
+	assert.ErrorIs(t, cfg.SetEnvProperty(config.DefaultEnv, "address", "localhost:7233"), config.ErrReadOnly)
+	assert.ErrorIs(t, cfg.SetAlias("wl", "workflow list"), config.ErrReadOnly)
+	assert.Equal(t, "env:\n  default:\n    address: ci:7233\n", readConfig(t, cfg))
+}
+
+func TestNewConfigLegacyPath(t *testing.T) {
+	// comments and unknown keys are kept when the file is moved
+	legacyContent := "# legacy config\nenv:\n  default:\n    address: legacy:7233\nunknown: value\n"
+
+	testcases := map[string]struct {
+		current    string
+		readOnly   bool
+		expect     string
+		expectPath string
+		keepLegacy bool
+	}{
+		"moves legacy config": {
+			expect:     "legacy:7233",
+			expectPath: "current",
+		},
+		"keeps existing config": {
+			current:    "env:\n  default:\n    address: current:7233\n",
+			expect:     "current:7233",
+			expectPath: "current",
+			keepLegacy: true,
+		},
+		"reads legacy config when read-only": {
+			readOnly:   true,
+			expect:     "legacy:7233",
+			expectPath: "legacy",
+			keepLegacy: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			legacyPath := filepath.Join(t.TempDir(), "legacy.yaml")
+			writeConfig(t, legacyPath, legacyContent)
+
+			currentPath := filepath.Join(dir, "test.yaml")
+			if tc.current != "" {
+				writeConfig(t, currentPath, tc.current)
+			}
+
+			cfg, err := config.NewConfig(appName, "test",
+				config.WithDir(dir), config.WithLegacyPath(legacyPath), config.WithReadOnly(tc.readOnly))
+			assert.NoError(t, err)
+
+			value, err := cfg.EnvProperty(config.DefaultEnv, "address")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, value)
+
+			paths := map[string]string{"current": currentPath, "legacy": legacyPath}
+			assert.Equal(t, paths[tc.expectPath], cfg.Path())
+
+			_, err = os.Stat(legacyPath)
+			if tc.keepLegacy {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, os.ErrNotExist)
+
+				fileInfo, err := os.Stat(currentPath)
+				assert.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600).String(), fileInfo.Mode().String())
+				assert.Equal(t, legacyContent, readConfig(t, cfg))
+			}
+		})
+	}
+}
+
+func TestNewConfigLegacyPath_ExplicitPath(t *testing.T) {
+	testcases := map[string]bool{
+		"path option":  false,
+		"path env var": true,
+	}
+
+	for name, fromEnv := range testcases {
+		t.Run(name, func(t *testing.T) {
+			legacyPath := filepath.Join(t.TempDir(), "legacy.yaml")
+			writeConfig(t, legacyPath, "env:\n  default:\n    address: legacy:7233\n")
+			path := filepath.Join(t.TempDir(), "ci", "explicit.yaml")
+
+			opts := []config.Option{config.WithDir(t.TempDir()), config.WithLegacyPath(legacyPath)}
+			if fromEnv {
+				t.Setenv(config.PathEnvVar(appName), path)
+			} else {
+				t.Setenv(config.PathEnvVar(appName), "")
+				opts = append(opts, config.WithPath(path))
+			}
+
+			cfg, err := config.NewConfig(appName, "test", opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, path, cfg.Path())
+
+			// the legacy file isn't moved to the explicit path
+			_, err = os.Stat(legacyPath)
+			assert.NoError(t, err)
+			_, err = os.Stat(path)
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestNewConfigLegacyPath_NotFound(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := config.NewConfig(appName, "test",
+		config.WithDir(dir), config.WithLegacyPath(filepath.Join(dir, "missing.yaml")))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "test.yaml"), cfg.Path())
+}
+
+func TestConfigureApp(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, "test.yaml"), "env:\n  default:\n    address: dir:7233\n")
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+	writeConfig(t, path, "env:\n  default:\n    address: ci:7233\n  prod:\n    address: prod:7233\n")
+
+	testcases := map[string]struct {
+		args       []string
+		envPath    string
+		expect     string
+		expectPath string
+	}{
+		"config dir": {
+			args:       []string{"workflow", "list"},
+			expect:     "dir:7233",
+			expectPath: filepath.Join(dir, "test.yaml"),
+		},
+		"config flag": {
+			args:       []string{"--config", path, "--env", "prod", "workflow", "list"},
+			expect:     "prod:7233",
+			expectPath: path,
+		},
+		"config env var": {
+			args:       []string{"workflow", "list"},
+			envPath:    path,
+			expect:     "ci:7233",
+			expectPath: path,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(config.PathEnvVar(appName), tc.envPath)
+
+			var address, cfgPath string
+			app := cli.NewApp()
+			app.Name = "app"
+			app.Commands = []*cli.Command{{
+				Name: "workflow",
+				Subcommands: []*cli.Command{{
+					Name:  "list",
+					Flags: []cli.Flag{&cli.StringFlag{Name: "address"}},
+					Action: func(c *cli.Context) error {
+						address = c.String("address")
+						cfgPath = config.FromContext(c).Path()
+						return nil
+					},
+				}},
+			}}
+			config.ConfigureApp(app, appName, "test", config.WithDir(dir))
+
+			err := app.Run(append([]string{"app"}, tc.args...))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, address)
+			assert.Equal(t, tc.expectPath, cfgPath)
+		})
+	}
+}
+
+func TestConfigureApp_Completion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+	writeConfig(t, path, "env:\n  ci:\n    address: ci:7233\n")
+
+	args := []string{"app", "--config", path, "--env", "--generate-bash-completion"}
+	osArgs := os.Args
+	os.Args = args
+	defer func() { os.Args = osArgs }()
+
+	var buf bytes.Buffer
+	app := cli.NewApp()
+	app.EnableBashCompletion = true
+	app.Writer = &buf
+	config.ConfigureApp(app, appName, "test", config.WithDir(t.TempDir()))
+
+	err := app.Run(args)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci\n", buf.String())
+}
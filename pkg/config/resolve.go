@@ -0,0 +1,161 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Origin is the source of an effective property value
+type Origin string
+
+const (
+	OriginFlag       Origin = "flag"
+	OriginEnvVar     Origin = "env var"
+	OriginEnv        Origin = "env"
+	OriginDefaultEnv Origin = "default env"
+)
+
+// Value is an effective property value and where it came from
+type Value struct {
+	Key    string
+	Value  string
+	Origin Origin
+	// Source names the flag, environment variable or config section of the value, ex. "TEMPORAL_ENV_PROD_ADDRESS"
+	Source string
+}
+
+// FlagLookup returns the value of the flag named key and whether it's set explicitly
+type FlagLookup func(key string) (string, bool)
+
+// CLIFlags looks up the flags set in the command or its parent commands
+func CLIFlags(c *cli.Context) FlagLookup {
+	return func(key string) (string, bool) {
+		if !c.IsSet(key) {
+			return "", false
+		}
+		return c.String(key), true
+	}
+}
+
+// EnvVarName returns the environment variable overriding a property of env, ex. TEMPORAL_ENV_PROD_ADDRESS
+func (c *Config) EnvVarName(env, key string) string {
+	return envVarPrefix(c.appName, env) + envVarWord(key)
+}
+
+// ResolveProperty returns the effective value of a property of env. The value is taken from the first source
+// that has it: the flag, the environment variable, the env section, then the default env section
+func (c *Config) ResolveProperty(env, key string, flags FlagLookup) (Value, bool, error) {
+	if env == "" {
+		env = DefaultEnv
+	}
+	if err := validateKey(env); err != nil {
+		return Value{}, false, fmt.Errorf("invalid env name: %w", err)
+	}
+
+	if flags != nil {
+		if value, ok := flags(key); ok {
+			return Value{Key: key, Value: value, Origin: OriginFlag, Source: "--" + key}, true, nil
+		}
+	}
+
+	envVar := c.EnvVarName(env, key)
+	if value, ok := os.LookupEnv(envVar); ok {
+		return Value{Key: key, Value: value, Origin: OriginEnvVar, Source: envVar}, true, nil
+	}
+
+	if value, ok := c.Envs[env][key]; ok {
+		return Value{Key: key, Value: value, Origin: OriginEnv, Source: c.sectionSource(env)}, true, nil
+	}
+
+	if value, ok := c.Envs[DefaultEnv][key]; ok {
+		return Value{Key: key, Value: value, Origin: OriginDefaultEnv, Source: c.sectionSource(DefaultEnv)}, true, nil
+	}
+
+	return Value{}, false, nil
+}
+
+// Resolve returns the effective values of env sorted by key. It includes the properties of the env and
+// default env sections and the given keys, ex. flag names. Environment variables override the values of these
+// keys only, as a variable name can't be mapped back to a key: TEMPORAL_ENV_PROD_EU_ADDRESS may be the
+// eu_address property of env prod, or the address property of env prod-eu
+func (c *Config) Resolve(env string, flags FlagLookup, keys ...string) ([]Value, error) {
+	if env == "" {
+		env = DefaultEnv
+	}
+	if err := validateKey(env); err != nil {
+		return nil, fmt.Errorf("invalid env name: %w", err)
+	}
+
+	known := map[string]bool{}
+	for _, key := range keys {
+		known[key] = true
+	}
+	for key := range c.Envs[DefaultEnv] {
+		known[key] = true
+	}
+	for key := range c.Envs[env] {
+		known[key] = true
+	}
+
+	var values []Value
+	for key := range known {
+		value, ok, err := c.ResolveProperty(env, key, flags)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values = append(values, value)
+		}
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i].Key < values[j].Key })
+
+	return values, nil
+}
+
+func (c *Config) sectionSource(env string) string {
+	return fmt.Sprintf("%s:env.%s", c.Path(), env)
+}
+
+func envVarPrefix(appName, env string) string {
+	return envVarWord(appName) + "_ENV_" + envVarWord(env) + "_"
+}
+
+// envVarWord uppercases s and replaces the characters not allowed in environment variable names with "_"
+func envVarWord(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
@@ -0,0 +1,158 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/config"
+)
+
+const resolveConfig = `env:
+  default:
+    address: localhost:7233
+    namespace: default
+  prod:
+    address: prod:7233
+    tls-cert-path: /certs/prod.pem`
+
+func TestEnvVarName(t *testing.T) {
+	cfg, teardown := setupConfig(t, resolveConfig)
+	defer teardown()
+
+	assert.Equal(t, "TEST_TCTL_KIT_ENV_PROD_ADDRESS", cfg.EnvVarName("prod", "address"))
+	assert.Equal(t, "TEST_TCTL_KIT_ENV_PROD_EU_TLS_CERT_PATH", cfg.EnvVarName("prod-eu", "tls-cert-path"))
+}
+
+func TestResolveProperty(t *testing.T) {
+	testcases := map[string]struct {
+		env       string
+		key       string
+		flags     map[string]string
+		envVars   map[string]string
+		expect    string
+		origin    config.Origin
+		source    string
+		notFound  bool
+		expectErr bool
+	}{
+		"flag overrides all": {
+			env: "prod", key: "address",
+			flags:   map[string]string{"address": "flag:7233"},
+			envVars: map[string]string{"TEST_TCTL_KIT_ENV_PROD_ADDRESS": "var:7233"},
+			expect:  "flag:7233", origin: config.OriginFlag, source: "--address",
+		},
+		"env var overrides env": {
+			env: "prod", key: "address",
+			envVars: map[string]string{"TEST_TCTL_KIT_ENV_PROD_ADDRESS": "var:7233"},
+			expect:  "var:7233", origin: config.OriginEnvVar, source: "TEST_TCTL_KIT_ENV_PROD_ADDRESS",
+		},
+		"env var of other env is ignored": {
+			env: "prod", key: "address",
+			envVars: map[string]string{"TEST_TCTL_KIT_ENV_DEFAULT_ADDRESS": "var:7233"},
+			expect:  "prod:7233", origin: config.OriginEnv,
+		},
+		"env overrides default env": {
+			env: "prod", key: "address",
+			expect: "prod:7233", origin: config.OriginEnv,
+		},
+		"falls back to default env": {
+			env: "prod", key: "namespace",
+			expect: "default", origin: config.OriginDefaultEnv,
+		},
+		"falls back to default env of unknown env": {
+			env: "staging", key: "address",
+			expect: "localhost:7233", origin: config.OriginDefaultEnv,
+		},
+		"empty env is default env": {
+			key:    "address",
+			expect: "localhost:7233", origin: config.OriginEnv,
+		},
+		"not found": {
+			env: "prod", key: "codec-endpoint",
+			notFound: true,
+		},
+		"throws on invalid env name": {
+			env: "prod!", key: "address",
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			cfg, teardown := setupConfig(t, resolveConfig)
+			defer teardown()
+
+			for k, v := range tc.envVars {
+				t.Setenv(k, v)
+			}
+
+			flags := func(key string) (string, bool) {
+				value, ok := tc.flags[key]
+				return value, ok
+			}
+
+			value, ok, err := cfg.ResolveProperty(tc.env, tc.key, flags)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, !tc.notFound, ok)
+			assert.Equal(t, tc.expect, value.Value)
+			assert.Equal(t, tc.origin, value.Origin)
+			if tc.source != "" {
+				assert.Equal(t, tc.source, value.Source)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	cfg, teardown := setupConfig(t, resolveConfig)
+	defer teardown()
+
+	t.Setenv("TEST_TCTL_KIT_ENV_PROD_TLS_CERT_PATH", "/certs/ci.pem")
+	t.Setenv("TEST_TCTL_KIT_ENV_PROD_CODEC_AUTH", "token")
+	// the variables of other envs and unknown keys are ignored
+	t.Setenv("TEST_TCTL_KIT_ENV_PROD_EU_ADDRESS", "prod-eu:7233")
+	t.Setenv("TEST_TCTL_KIT_ENV_PROD_UNKNOWN", "value")
+
+	flags := func(key string) (string, bool) {
+		if key == "namespace" {
+			return "orders", true
+		}
+		return "", false
+	}
+
+	values, err := cfg.Resolve("prod", flags, "namespace", "codec-auth", "output")
+	assert.NoError(t, err)
+
+	section := cfg.Path() + ":env.prod"
+	assert.Equal(t, []config.Value{
+		{Key: "address", Value: "prod:7233", Origin: config.OriginEnv, Source: section},
+		{Key: "codec-auth", Value: "token", Origin: config.OriginEnvVar, Source: "TEST_TCTL_KIT_ENV_PROD_CODEC_AUTH"},
+		{Key: "namespace", Value: "orders", Origin: config.OriginFlag, Source: "--namespace"},
+		{Key: "tls-cert-path", Value: "/certs/ci.pem", Origin: config.OriginEnvVar, Source: "TEST_TCTL_KIT_ENV_PROD_TLS_CERT_PATH"},
+	}, values)
+}
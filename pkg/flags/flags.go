@@ -28,6 +28,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/temporalio/tctl-kit/pkg/format"
 	"github.com/temporalio/tctl-kit/pkg/output"
 	"github.com/temporalio/tctl-kit/pkg/pager"
@@ -38,10 +39,14 @@ var FlagsForPagination = []cli.Flag{
 		Name:  output.FlagLimit,
 		Usage: "number of items to print",
 	},
+	&cli.IntFlag{
+		Name:    output.FlagOffset,
+		Aliases: []string{"skip"},
+		Usage:   "number of items to skip before printing",
+	},
 	&cli.StringFlag{
-		Name:    pager.FlagPager,
-		Usage:   "pager to use: less, more, favoritePager..",
-		EnvVars: []string{"PAGER"},
+		Name:  pager.FlagPager,
+		Usage: "pager command to use: less, more, interactive (print a page at a time), \"less -S\".. Can also be set with $<APP>_PAGER, the config file or $PAGER",
 	},
 	&cli.BoolFlag{
 		Name:    pager.FlagNoPager,
@@ -64,8 +69,26 @@ var FlagsForRendering = []cli.Flag{
 	},
 	&cli.StringFlag{
 		Name:  output.FlagFields,
-		Usage: "customize fields to print. Set to 'long' to automatically print more of main fields",
+		Usage: "customize fields to print. Set to 'long' to automatically print more of main fields. Fields support paths such as Execution.Memo[\"key\"], Tags[0], Tags[*] and column names such as Id=Execution.WorkflowId",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagTemplate,
+		Usage: "Go template to print each item with, ex. '{{.Name}}: {{color \"green\" .Status}}'. Implies --output template",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagFilter,
+		Usage: "print only the items matching an expression, ex. 'Status == \"Running\" && StartTime > -1h'. Supports ==, !=, <, <=, >, >=, =~, !~, &&, ||, !",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagSortBy,
+		Usage: "sort items by fields. Add 'desc' after a field to sort in descending order, ex. 'StartTime,desc,Name'",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagWide,
+		Usage: "print table columns in full width instead of fitting them into the terminal",
 	},
+	color.Flag,
+	color.ThemeFlag,
 }
 
 var FlagsForPaginationAndRendering = append(FlagsForPagination, FlagsForRendering...)
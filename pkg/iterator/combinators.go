@@ -0,0 +1,309 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator
+
+import (
+	"context"
+)
+
+// FromSlice returns an iterator over the items of a slice
+func FromSlice[T any](items []T) ContextIterator[T] {
+	return &sliceIterator[T]{items: items, pos: -1}
+}
+
+// Collect reads all items of the iterator and closes it
+func Collect[T any](ctx context.Context, iter ContextIterator[T]) ([]T, error) {
+	defer iter.Close()
+
+	var items []T
+	for iter.Next(ctx) {
+		items = append(items, iter.Value())
+	}
+
+	return items, iter.Err()
+}
+
+// Map returns an iterator that converts the items with f. An error returned by f ends the iteration.
+func Map[T, U any](iter ContextIterator[T], f func(T) (U, error)) ContextIterator[U] {
+	return &mapIterator[T, U]{source: iter, f: f}
+}
+
+// Filter returns an iterator over the items for which f returns true. An error returned by f ends the iteration.
+func Filter[T any](iter ContextIterator[T], f func(T) (bool, error)) ContextIterator[T] {
+	return &filterIterator[T]{source: iter, f: f}
+}
+
+// Take returns an iterator over the first n items. The source isn't advanced past the n-th item.
+func Take[T any](iter ContextIterator[T], n int) ContextIterator[T] {
+	return &takeIterator[T]{source: iter, n: n}
+}
+
+// Skip returns an iterator over the items after the first n items
+func Skip[T any](iter ContextIterator[T], n int) ContextIterator[T] {
+	return &skipIterator[T]{source: iter, n: n}
+}
+
+// Chain returns an iterator over the items of the iterators one after another
+func Chain[T any](iters ...ContextIterator[T]) ContextIterator[T] {
+	return &chainIterator[T]{sources: iters}
+}
+
+// Batch returns an iterator over slices of up to size items. The last batch may be smaller.
+// Items received before the source failed or the context was done are returned in a batch before the error.
+func Batch[T any](iter ContextIterator[T], size int) ContextIterator[[]T] {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &batchIterator[T]{source: iter, size: size}
+}
+
+type sliceIterator[T any] struct {
+	items []T
+	pos   int
+	err   error
+}
+
+func (s *sliceIterator[T]) Next(ctx context.Context) bool {
+	if s.err != nil || s.pos+1 >= len(s.items) {
+		return false
+	}
+
+	if err := ctx.Err(); err != nil {
+		s.err = err
+		return false
+	}
+
+	s.pos++
+	return true
+}
+
+func (s *sliceIterator[T]) Value() T {
+	return s.items[s.pos]
+}
+
+func (s *sliceIterator[T]) Err() error {
+	return s.err
+}
+
+func (s *sliceIterator[T]) Close() error {
+	return nil
+}
+
+type mapIterator[T, U any] struct {
+	source ContextIterator[T]
+	f      func(T) (U, error)
+	value  U
+	err    error
+}
+
+func (m *mapIterator[T, U]) Next(ctx context.Context) bool {
+	if m.err != nil || !m.source.Next(ctx) {
+		return false
+	}
+
+	m.value, m.err = m.f(m.source.Value())
+	return m.err == nil
+}
+
+func (m *mapIterator[T, U]) Value() U {
+	return m.value
+}
+
+func (m *mapIterator[T, U]) Err() error {
+	if m.err != nil {
+		return m.err
+	}
+	return m.source.Err()
+}
+
+func (m *mapIterator[T, U]) Close() error {
+	return m.source.Close()
+}
+
+type filterIterator[T any] struct {
+	source ContextIterator[T]
+	f      func(T) (bool, error)
+	err    error
+}
+
+func (f *filterIterator[T]) Next(ctx context.Context) bool {
+	for f.err == nil && f.source.Next(ctx) {
+		var ok bool
+		if ok, f.err = f.f(f.source.Value()); ok && f.err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *filterIterator[T]) Value() T {
+	return f.source.Value()
+}
+
+func (f *filterIterator[T]) Err() error {
+	if f.err != nil {
+		return f.err
+	}
+	return f.source.Err()
+}
+
+func (f *filterIterator[T]) Close() error {
+	return f.source.Close()
+}
+
+type takeIterator[T any] struct {
+	source ContextIterator[T]
+	n      int
+	taken  int
+}
+
+func (t *takeIterator[T]) Next(ctx context.Context) bool {
+	if t.taken >= t.n || !t.source.Next(ctx) {
+		return false
+	}
+
+	t.taken++
+	return true
+}
+
+func (t *takeIterator[T]) Value() T {
+	return t.source.Value()
+}
+
+func (t *takeIterator[T]) Err() error {
+	return t.source.Err()
+}
+
+func (t *takeIterator[T]) Close() error {
+	return t.source.Close()
+}
+
+type skipIterator[T any] struct {
+	source  ContextIterator[T]
+	n       int
+	skipped int
+}
+
+func (s *skipIterator[T]) Next(ctx context.Context) bool {
+	for ; s.skipped < s.n; s.skipped++ {
+		if !s.source.Next(ctx) {
+			return false
+		}
+	}
+
+	return s.source.Next(ctx)
+}
+
+func (s *skipIterator[T]) Value() T {
+	return s.source.Value()
+}
+
+func (s *skipIterator[T]) Err() error {
+	return s.source.Err()
+}
+
+func (s *skipIterator[T]) Close() error {
+	return s.source.Close()
+}
+
+type chainIterator[T any] struct {
+	sources []ContextIterator[T]
+	pos     int
+}
+
+func (c *chainIterator[T]) Next(ctx context.Context) bool {
+	for ; c.pos < len(c.sources); c.pos++ {
+		source := c.sources[c.pos]
+		if source.Next(ctx) {
+			return true
+		}
+		if source.Err() != nil {
+			return false
+		}
+	}
+
+	return false
+}
+
+func (c *chainIterator[T]) Value() T {
+	return c.sources[c.pos].Value()
+}
+
+func (c *chainIterator[T]) Err() error {
+	if c.pos < len(c.sources) {
+		return c.sources[c.pos].Err()
+	}
+	return nil
+}
+
+func (c *chainIterator[T]) Close() error {
+	var errs []error
+	for _, source := range c.sources {
+		if err := source.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+type batchIterator[T any] struct {
+	source ContextIterator[T]
+	size   int
+	batch  []T
+	done   bool
+}
+
+func (b *batchIterator[T]) Next(ctx context.Context) bool {
+	if b.done {
+		return false
+	}
+
+	b.batch = make([]T, 0, b.size)
+	for len(b.batch) < b.size {
+		if !b.source.Next(ctx) {
+			b.done = true
+			break
+		}
+		b.batch = append(b.batch, b.source.Value())
+	}
+
+	return len(b.batch) > 0
+}
+
+func (b *batchIterator[T]) Value() []T {
+	return b.batch
+}
+
+func (b *batchIterator[T]) Err() error {
+	return b.source.Err()
+}
+
+func (b *batchIterator[T]) Close() error {
+	return b.source.Close()
+}
@@ -0,0 +1,190 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/iterator"
+)
+
+func ExampleBatch() {
+	items := iterator.FromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	even := iterator.Filter(items, func(i int) (bool, error) { return i%2 == 0, nil })
+	squares := iterator.Map(even, func(i int) (int, error) { return i * i, nil })
+	batches := iterator.Batch(iterator.Take(squares, 3), 2)
+
+	for batches.Next(context.Background()) {
+		fmt.Println(batches.Value())
+	}
+	// Output:
+	// [4 16]
+	// [36]
+}
+
+func ExampleChain() {
+	iter := iterator.Chain(iterator.FromSlice([]string{"a", "b"}), iterator.FromSlice([]string{}), iterator.FromSlice([]string{"c"}))
+
+	items, err := iterator.Collect(context.Background(), iter)
+	fmt.Println(items, err)
+	// Output:
+	// [a b c] <nil>
+}
+
+func TestTake(t *testing.T) {
+	tests := map[string]struct {
+		items []int
+		n     int
+		want  []int
+	}{
+		"fewer items": {items: []int{1, 2}, n: 3, want: []int{1, 2}},
+		"more items":  {items: []int{1, 2, 3, 4}, n: 3, want: []int{1, 2, 3}},
+		"zero":        {items: []int{1, 2}, n: 0, want: nil},
+		"negative":    {items: []int{1, 2}, n: -1, want: nil},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			items, err := iterator.Collect(context.Background(), iterator.Take(iterator.FromSlice(tt.items), tt.n))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, items)
+		})
+	}
+}
+
+func TestSkip(t *testing.T) {
+	tests := map[string]struct {
+		items []int
+		n     int
+		want  []int
+	}{
+		"none":     {items: []int{1, 2}, n: 0, want: []int{1, 2}},
+		"some":     {items: []int{1, 2, 3}, n: 2, want: []int{3}},
+		"all":      {items: []int{1, 2}, n: 2, want: nil},
+		"past end": {items: []int{1, 2}, n: 5, want: nil},
+		"negative": {items: []int{1, 2}, n: -1, want: []int{1, 2}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			items, err := iterator.Collect(context.Background(), iterator.Skip(iterator.FromSlice(tt.items), tt.n))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, items)
+		})
+	}
+}
+
+func TestTake_DoesNotFetchPastLimit(t *testing.T) {
+	source := &sliceIterator{items: []int{1, 2}, err: errors.New("fetch failed")}
+
+	items, err := iterator.Collect(context.Background(), iterator.Take(iterator.FromIterator[int](source), 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.True(t, source.closed)
+}
+
+func TestBatch(t *testing.T) {
+	tests := map[string]struct {
+		items []int
+		size  int
+		want  [][]int
+	}{
+		"empty":   {items: nil, size: 2, want: nil},
+		"exact":   {items: []int{1, 2, 3, 4}, size: 2, want: [][]int{{1, 2}, {3, 4}}},
+		"partial": {items: []int{1, 2, 3}, size: 2, want: [][]int{{1, 2}, {3}}},
+		"zero":    {items: []int{1, 2}, size: 0, want: [][]int{{1}, {2}}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			batches, err := iterator.Collect(context.Background(), iterator.Batch(iterator.FromSlice(tt.items), tt.size))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, batches)
+		})
+	}
+}
+
+func TestBatch_Error(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	batches := iterator.Batch(iterator.FromIterator[int](&sliceIterator{items: []int{1, 2, 3}, err: errFetch}), 2)
+
+	items, err := iterator.Collect(context.Background(), batches)
+	assert.ErrorIs(t, err, errFetch)
+	// items received before the error are returned
+	assert.Equal(t, [][]int{{1, 2}, {3}}, items)
+}
+
+func TestFilter_Error(t *testing.T) {
+	errMatch := errors.New("match failed")
+	iter := iterator.Filter(iterator.FromSlice([]int{1, 2, 3}), func(i int) (bool, error) {
+		if i == 2 {
+			return false, errMatch
+		}
+		return true, nil
+	})
+
+	items, err := iterator.Collect(context.Background(), iter)
+	assert.ErrorIs(t, err, errMatch)
+	assert.Equal(t, []int{1}, items)
+}
+
+func TestMap_Error(t *testing.T) {
+	errConvert := errors.New("convert failed")
+	iter := iterator.Map(iterator.FromSlice([]int{1, 2, 3}), func(i int) (string, error) {
+		if i == 3 {
+			return "", errConvert
+		}
+		return fmt.Sprint(i), nil
+	})
+
+	items, err := iterator.Collect(context.Background(), iter)
+	assert.ErrorIs(t, err, errConvert)
+	assert.Equal(t, []string{"1", "2"}, items)
+}
+
+func TestChain_Error(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	first := &sliceIterator{items: []int{1}, err: errFetch}
+	second := &sliceIterator{items: []int{2}}
+	iter := iterator.Chain(iterator.FromIterator[int](first), iterator.FromIterator[int](second))
+
+	items, err := iterator.Collect(context.Background(), iter)
+	assert.ErrorIs(t, err, errFetch)
+	assert.Equal(t, []int{1}, items)
+	assert.True(t, first.closed)
+	assert.True(t, second.closed)
+}
+
+func TestFromSlice_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := iterator.FromSlice([]int{1, 2})
+
+	assert.True(t, iter.Next(ctx))
+	cancel()
+	assert.False(t, iter.Next(ctx))
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
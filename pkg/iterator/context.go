@@ -0,0 +1,158 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ContextIterator represents the interface for iterator that fetches items with a context.
+// Unlike Iterator, it tells the end of items apart from a failed fetch and holds resources until closed:
+//
+//	defer iter.Close()
+//	for iter.Next(ctx) {
+//		item := iter.Value()
+//	}
+//	if err := iter.Err(); err != nil {
+//		return err
+//	}
+type ContextIterator[T any] interface {
+	// Next advances to the next item. It returns false when there are no more items,
+	// the context is done or fetching failed
+	Next(ctx context.Context) bool
+	// Value returns the current item
+	Value() T
+	// Err returns the error that ended the iteration, if any
+	Err() error
+	// Close releases the resources held by the iterator
+	Close() error
+}
+
+// FromIterator adapts an Iterator to a ContextIterator.
+// The context is checked before each item is fetched. Close closes the iterator if it implements io.Closer.
+func FromIterator[T any](iter Iterator[T]) ContextIterator[T] {
+	if adapter, ok := iter.(*contextIteratorAdapter[T]); ok {
+		return adapter.iter
+	}
+
+	return &iteratorAdapter[T]{iter: iter}
+}
+
+// ToIterator adapts a ContextIterator to an Iterator, fetching items with ctx.
+// An error that ended the iteration is returned by the last call to Next.
+func ToIterator[T any](ctx context.Context, iter ContextIterator[T]) Iterator[T] {
+	if adapter, ok := iter.(*iteratorAdapter[T]); ok {
+		return adapter.iter
+	}
+
+	return &contextIteratorAdapter[T]{ctx: ctx, iter: iter}
+}
+
+type iteratorAdapter[T any] struct {
+	iter  Iterator[T]
+	value T
+	err   error
+	done  bool
+}
+
+func (a *iteratorAdapter[T]) Next(ctx context.Context) bool {
+	if a.done {
+		return false
+	}
+
+	if err := ctx.Err(); err != nil {
+		a.err = err
+		a.done = true
+		return false
+	}
+
+	if !a.iter.HasNext() {
+		a.done = true
+		return false
+	}
+
+	value, err := a.iter.Next()
+	if err != nil {
+		a.err = err
+		a.done = true
+		return false
+	}
+
+	a.value = value
+	return true
+}
+
+func (a *iteratorAdapter[T]) Value() T {
+	return a.value
+}
+
+func (a *iteratorAdapter[T]) Err() error {
+	return a.err
+}
+
+func (a *iteratorAdapter[T]) Close() error {
+	a.done = true
+	if closer, ok := a.iter.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+type contextIteratorAdapter[T any] struct {
+	ctx      context.Context
+	iter     ContextIterator[T]
+	fetched  bool
+	hasValue bool
+	errSent  bool
+}
+
+func (a *contextIteratorAdapter[T]) HasNext() bool {
+	if !a.fetched {
+		a.hasValue = a.iter.Next(a.ctx)
+		a.fetched = true
+	}
+
+	return a.hasValue || (a.iter.Err() != nil && !a.errSent)
+}
+
+func (a *contextIteratorAdapter[T]) Next() (T, error) {
+	var zero T
+	if !a.HasNext() {
+		return zero, errors.New("no more items")
+	}
+	a.fetched = false
+
+	if !a.hasValue {
+		a.errSent = true
+		return zero, a.iter.Err()
+	}
+
+	return a.iter.Value(), nil
+}
+
+// Close closes the underlying ContextIterator
+func (a *contextIteratorAdapter[T]) Close() error {
+	return a.iter.Close()
+}
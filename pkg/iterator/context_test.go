@@ -0,0 +1,122 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/iterator"
+)
+
+type sliceIterator struct {
+	items  []int
+	err    error
+	closed bool
+}
+
+func (s *sliceIterator) HasNext() bool {
+	return len(s.items) > 0 || s.err != nil
+}
+
+func (s *sliceIterator) Next() (int, error) {
+	if len(s.items) == 0 {
+		err := s.err
+		s.err = nil
+		return 0, err
+	}
+
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item, nil
+}
+
+func (s *sliceIterator) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestFromIterator(t *testing.T) {
+	source := &sliceIterator{items: []int{1, 2, 3}}
+	iter := iterator.FromIterator[int](source)
+
+	var items []int
+	for iter.Next(context.Background()) {
+		items = append(items, iter.Value())
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, items)
+	assert.NoError(t, iter.Err())
+	assert.NoError(t, iter.Close())
+	assert.True(t, source.closed)
+}
+
+func TestFromIterator_Error(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	iter := iterator.FromIterator[int](&sliceIterator{items: []int{1}, err: errFetch})
+
+	assert.True(t, iter.Next(context.Background()))
+	assert.False(t, iter.Next(context.Background()))
+	assert.ErrorIs(t, iter.Err(), errFetch)
+}
+
+func TestFromIterator_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := iterator.FromIterator[int](&sliceIterator{items: []int{1, 2}})
+
+	assert.True(t, iter.Next(ctx))
+	cancel()
+	assert.False(t, iter.Next(ctx))
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+}
+
+func TestToIterator(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	source := iterator.FromIterator[int](&sliceIterator{items: []int{1, 2}, err: errFetch})
+
+	// unwraps the adapted iterator
+	assert.IsType(t, &sliceIterator{}, iterator.ToIterator(context.Background(), source))
+
+	iter := iterator.ToIterator[int](context.Background(), &wrapper{source})
+
+	var items []int
+	var err error
+	for iter.HasNext() {
+		var item int
+		if item, err = iter.Next(); err != nil {
+			break
+		}
+		items = append(items, item)
+	}
+
+	assert.Equal(t, []int{1, 2}, items)
+	assert.ErrorIs(t, err, errFetch)
+	assert.False(t, iter.HasNext())
+}
+
+// wrapper hides the adapter type to test the conversion of any ContextIterator
+type wrapper struct {
+	iterator.ContextIterator[int]
+}
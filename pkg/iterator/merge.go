@@ -0,0 +1,334 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MergeOptions customize the merge of iterators
+type MergeOptions[T any] struct {
+	// Parallelism limits the number of sources fetched at once, all sources if not set
+	Parallelism int
+	// Less orders the merged items. Each source must already be ordered by it.
+	// If not set, items are returned in the order they are received
+	Less func(a, b T) bool
+	// FailFast stops the merge at the first failed source.
+	// Otherwise the remaining sources are read and the errors are returned once they are done
+	FailFast bool
+}
+
+// SourceError is an error of one of the merged sources
+type SourceError struct {
+	// Source is the index of the failed source
+	Source int
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("source %v: %v", e.Source, e.Err)
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// MergeError lists the errors of the sources that failed during the merge
+type MergeError []*SourceError
+
+func (e MergeError) Error() string {
+	var msgs []string
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any source error matches target, errors.Is follows Unwrap() []error only from Go 1.20
+func (e MergeError) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first source error that matches target
+func (e MergeError) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e MergeError) Unwrap() []error {
+	var errs []error
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Merge returns an iterator over the items of all sources, fetched concurrently.
+// Close must be called to stop fetching, it closes the sources.
+func Merge[T any](sources []ContextIterator[T], opts *MergeOptions[T]) ContextIterator[T] {
+	if opts == nil {
+		opts = &MergeOptions[T]{}
+	}
+
+	parallelism := opts.Parallelism
+	if parallelism <= 0 || parallelism > len(sources) {
+		parallelism = len(sources)
+	}
+
+	return &mergeIterator[T]{
+		sources: sources,
+		opts:    opts,
+		sem:     make(chan struct{}, parallelism),
+		heads:   make([]*mergeItem[T], len(sources)),
+		drained: make([]bool, len(sources)),
+	}
+}
+
+type mergeItem[T any] struct {
+	source int
+	value  T
+	err    error
+}
+
+type mergeIterator[T any] struct {
+	sources []ContextIterator[T]
+	opts    *MergeOptions[T]
+	sem     chan struct{}
+	start   sync.Once
+	stop    context.CancelFunc
+	wg      sync.WaitGroup
+
+	// items of all sources when unordered
+	out chan mergeItem[T]
+	// items per source when ordered
+	chans   []chan mergeItem[T]
+	heads   []*mergeItem[T]
+	drained []bool
+
+	value  T
+	errs   MergeError
+	err    error
+	closed bool
+}
+
+func (m *mergeIterator[T]) Next(ctx context.Context) bool {
+	m.start.Do(func() {
+		var fetchCtx context.Context
+		fetchCtx, m.stop = context.WithCancel(ctx)
+		m.fetch(fetchCtx)
+	})
+
+	if m.err != nil {
+		return false
+	}
+
+	var ok bool
+	if m.opts.Less != nil {
+		ok = m.nextOrdered(ctx)
+	} else {
+		ok = m.nextUnordered(ctx)
+	}
+
+	if !ok && m.err == nil && len(m.errs) > 0 {
+		m.err = m.errs
+	}
+	return ok
+}
+
+func (m *mergeIterator[T]) fetch(ctx context.Context) {
+	if m.opts.Less == nil {
+		m.out = make(chan mergeItem[T])
+	} else {
+		m.chans = make([]chan mergeItem[T], len(m.sources))
+	}
+
+	for i := range m.sources {
+		out := m.out
+		if m.chans != nil {
+			m.chans[i] = make(chan mergeItem[T], 1)
+			out = m.chans[i]
+		}
+
+		m.wg.Add(1)
+		go func(i int, out chan mergeItem[T]) {
+			defer m.wg.Done()
+			if m.chans != nil {
+				defer close(out)
+			}
+			m.read(ctx, i, out)
+		}(i, out)
+	}
+
+	if m.out != nil {
+		go func() {
+			m.wg.Wait()
+			close(m.out)
+		}()
+	}
+}
+
+// read sends the items of the source to out, holding a slot of the semaphore while fetching
+func (m *mergeIterator[T]) read(ctx context.Context, i int, out chan<- mergeItem[T]) {
+	source := m.sources[i]
+	for {
+		select {
+		case m.sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		ok := source.Next(ctx)
+		<-m.sem
+
+		item := mergeItem[T]{source: i}
+		if ok {
+			item.value = source.Value()
+		} else if item.err = source.Err(); item.err == nil {
+			return
+		}
+
+		select {
+		case out <- item:
+		case <-ctx.Done():
+			return
+		}
+
+		if !ok {
+			return
+		}
+	}
+}
+
+func (m *mergeIterator[T]) nextUnordered(ctx context.Context) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			m.err = ctx.Err()
+			return false
+		case item, ok := <-m.out:
+			if !ok {
+				return false
+			}
+			if item.err != nil {
+				if m.fail(item) {
+					return false
+				}
+				continue
+			}
+			m.value = item.value
+			return true
+		}
+	}
+}
+
+func (m *mergeIterator[T]) nextOrdered(ctx context.Context) bool {
+	// every source has to have its next item ready to pick the least one
+	for i := range m.sources {
+		for !m.drained[i] && m.heads[i] == nil {
+			select {
+			case <-ctx.Done():
+				m.err = ctx.Err()
+				return false
+			case item, ok := <-m.chans[i]:
+				if !ok {
+					m.drained[i] = true
+				} else if item.err != nil {
+					m.drained[i] = true
+					if m.fail(item) {
+						return false
+					}
+				} else {
+					m.heads[i] = &item
+				}
+			}
+		}
+	}
+
+	least := -1
+	for i, head := range m.heads {
+		if head != nil && (least < 0 || m.opts.Less(head.value, m.heads[least].value)) {
+			least = i
+		}
+	}
+	if least < 0 {
+		return false
+	}
+
+	m.value = m.heads[least].value
+	m.heads[least] = nil
+	return true
+}
+
+// fail records the error of a source and reports whether the merge has to stop
+func (m *mergeIterator[T]) fail(item mergeItem[T]) bool {
+	err := &SourceError{Source: item.source, Err: item.err}
+	if m.opts.FailFast {
+		m.err = err
+		m.stop()
+		return true
+	}
+
+	m.errs = append(m.errs, err)
+	return false
+}
+
+func (m *mergeIterator[T]) Value() T {
+	return m.value
+}
+
+func (m *mergeIterator[T]) Err() error {
+	return m.err
+}
+
+func (m *mergeIterator[T]) Close() error {
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	m.start.Do(func() {})
+	if m.stop != nil {
+		m.stop()
+	}
+	m.wg.Wait()
+
+	var err error
+	for _, source := range m.sources {
+		if closeErr := source.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,181 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/iterator"
+)
+
+func ExampleMerge() {
+	sources := []iterator.ContextIterator[int]{
+		iterator.FromSlice([]int{1, 4, 7}),
+		iterator.FromSlice([]int{2, 3, 9}),
+		iterator.FromSlice([]int{5, 6, 8}),
+	}
+
+	iter := iterator.Merge(sources, &iterator.MergeOptions[int]{
+		Less: func(a, b int) bool { return a < b },
+	})
+
+	items, err := iterator.Collect(context.Background(), iter)
+	fmt.Println(items, err)
+	// Output:
+	// [1 2 3 4 5 6 7 8 9] <nil>
+}
+
+// slowSource tracks the number of sources fetching at once
+type slowSource struct {
+	iterator.ContextIterator[int]
+	fetching *int32
+	maxSeen  *int32
+	closed   bool
+}
+
+func (s *slowSource) Next(ctx context.Context) bool {
+	n := atomic.AddInt32(s.fetching, 1)
+	defer atomic.AddInt32(s.fetching, -1)
+
+	for {
+		max := atomic.LoadInt32(s.maxSeen)
+		if n <= max || atomic.CompareAndSwapInt32(s.maxSeen, max, n) {
+			break
+		}
+	}
+
+	time.Sleep(time.Millisecond)
+	return s.ContextIterator.Next(ctx)
+}
+
+func (s *slowSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestMerge_Unordered(t *testing.T) {
+	var fetching, maxSeen int32
+	var sources []iterator.ContextIterator[int]
+	var slow []*slowSource
+	for i := 0; i < 5; i++ {
+		source := &slowSource{
+			ContextIterator: iterator.FromSlice([]int{i * 10, i*10 + 1, i*10 + 2}),
+			fetching:        &fetching,
+			maxSeen:         &maxSeen,
+		}
+		slow = append(slow, source)
+		sources = append(sources, source)
+	}
+
+	items, err := iterator.Collect(context.Background(), iterator.Merge(sources, &iterator.MergeOptions[int]{Parallelism: 2}))
+	assert.NoError(t, err)
+
+	sort.Ints(items)
+	assert.Equal(t, []int{0, 1, 2, 10, 11, 12, 20, 21, 22, 30, 31, 32, 40, 41, 42}, items)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxSeen), int32(2))
+	for _, source := range slow {
+		assert.True(t, source.closed)
+	}
+}
+
+func TestMerge_Errors(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	newSources := func() []iterator.ContextIterator[int] {
+		return []iterator.ContextIterator[int]{
+			iterator.FromSlice([]int{1, 3}),
+			iterator.FromIterator[int](&sliceIterator{err: errFetch}),
+			iterator.FromSlice([]int{2, 4}),
+		}
+	}
+	less := func(a, b int) bool { return a < b }
+
+	tests := map[string]struct {
+		opts      *iterator.MergeOptions[int]
+		wantItems []int
+	}{
+		"continue unordered": {opts: &iterator.MergeOptions[int]{}, wantItems: []int{1, 2, 3, 4}},
+		"continue ordered":   {opts: &iterator.MergeOptions[int]{Less: less}, wantItems: []int{1, 2, 3, 4}},
+		"fail fast ordered":  {opts: &iterator.MergeOptions[int]{Less: less, FailFast: true}, wantItems: nil},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			items, err := iterator.Collect(context.Background(), iterator.Merge(newSources(), tt.opts))
+
+			assert.ErrorIs(t, err, errFetch)
+			var sourceErr *iterator.SourceError
+			if assert.ErrorAs(t, err, &sourceErr) {
+				assert.Equal(t, 1, sourceErr.Source)
+			}
+
+			// source errors are matched without Unwrap() []error, which errors.Is follows only from Go 1.20
+			if !tt.opts.FailFast {
+				mergeErr, ok := err.(iterator.MergeError)
+				if assert.True(t, ok) {
+					assert.True(t, mergeErr.Is(errFetch))
+					var sourceErr *iterator.SourceError
+					assert.True(t, mergeErr.As(&sourceErr))
+				}
+			}
+
+			sort.Ints(items)
+			assert.Equal(t, tt.wantItems, items)
+		})
+	}
+}
+
+func TestMerge_FailFast(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	blocking := iterator.NewPageIterator(func(ctx context.Context, token []byte) ([]int, []byte, error) {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}, nil)
+	sources := []iterator.ContextIterator[int]{blocking, iterator.FromIterator[int](&sliceIterator{err: errFetch})}
+
+	items, err := iterator.Collect(context.Background(), iterator.Merge(sources, &iterator.MergeOptions[int]{FailFast: true}))
+	assert.Empty(t, items)
+	assert.ErrorIs(t, err, errFetch)
+	assert.Equal(t, "source 1: fetch failed", err.Error())
+}
+
+func TestMerge_Cancel(t *testing.T) {
+	blocking := iterator.NewPageIterator(func(ctx context.Context, token []byte) ([]int, []byte, error) {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := iterator.Merge([]iterator.ContextIterator[int]{blocking}, nil)
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	assert.False(t, iter.Next(ctx))
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+	assert.NoError(t, iter.Close())
+}
@@ -0,0 +1,149 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultPrefetchPages is the number of pages fetched ahead of the page being read
+const DefaultPrefetchPages = 1
+
+// PageFunc fetches the page of items for the token. The first page is fetched with an empty token.
+// An empty next token means there are no more pages. Items returned with an error are read before the error is reported.
+type PageFunc[T any] func(ctx context.Context, token []byte) (items []T, next []byte, err error)
+
+// PageOptions customize the page iterator
+type PageOptions struct {
+	// Prefetch is the number of pages buffered ahead of the page being read, DefaultPrefetchPages if not set
+	Prefetch int
+}
+
+// NewPageIterator returns an iterator over the items of the pages returned by fetch, such as gRPC List APIs
+// with NextPageToken. The next pages are fetched in the background while the current page is read.
+// Close must be called to stop fetching.
+func NewPageIterator[T any](fetch PageFunc[T], opts *PageOptions) ContextIterator[T] {
+	prefetch := DefaultPrefetchPages
+	if opts != nil && opts.Prefetch > 0 {
+		prefetch = opts.Prefetch
+	}
+
+	return &pageIterator[T]{
+		fetch: fetch,
+		pages: make(chan page[T], prefetch),
+		done:  make(chan struct{}),
+	}
+}
+
+type page[T any] struct {
+	items []T
+	err   error
+}
+
+type pageIterator[T any] struct {
+	fetch PageFunc[T]
+	pages chan page[T]
+	done  chan struct{}
+	start sync.Once
+	stop  context.CancelFunc
+	items []T
+	pos   int
+	// pageErr is the error of the page being read, reported once its items are read
+	pageErr error
+	err     error
+}
+
+func (p *pageIterator[T]) Next(ctx context.Context) bool {
+	p.start.Do(func() {
+		var fetchCtx context.Context
+		fetchCtx, p.stop = context.WithCancel(ctx)
+		go p.prefetch(fetchCtx)
+	})
+
+	for p.err == nil {
+		if p.pos+1 < len(p.items) {
+			p.pos++
+			return true
+		}
+		if p.pageErr != nil {
+			p.err = p.pageErr
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			p.err = ctx.Err()
+		case page, ok := <-p.pages:
+			if !ok {
+				return false
+			}
+			p.items, p.pos, p.pageErr = page.items, -1, page.err
+		}
+	}
+
+	return false
+}
+
+func (p *pageIterator[T]) prefetch(ctx context.Context) {
+	defer close(p.done)
+	defer close(p.pages)
+
+	var token []byte
+	for {
+		items, next, err := p.fetch(ctx, token)
+
+		select {
+		case <-ctx.Done():
+			return
+		case p.pages <- page[T]{items: items, err: err}:
+		}
+
+		if err != nil || len(next) == 0 {
+			return
+		}
+		token = next
+	}
+}
+
+func (p *pageIterator[T]) Value() T {
+	return p.items[p.pos]
+}
+
+func (p *pageIterator[T]) Err() error {
+	return p.err
+}
+
+func (p *pageIterator[T]) Close() error {
+	p.start.Do(func() {
+		// never started, nothing to stop
+		close(p.done)
+	})
+
+	if p.stop != nil {
+		p.stop()
+	}
+	<-p.done
+
+	return nil
+}
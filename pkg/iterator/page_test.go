@@ -0,0 +1,148 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package iterator_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/iterator"
+)
+
+// pages returns a fetch function over the pages, with the page index as the token
+func pages(items ...[]string) (iterator.PageFunc[string], *int32) {
+	var fetched int32
+	return func(ctx context.Context, token []byte) ([]string, []byte, error) {
+		atomic.AddInt32(&fetched, 1)
+
+		i := 0
+		if len(token) > 0 {
+			i, _ = strconv.Atoi(string(token))
+		}
+
+		var next []byte
+		if i+1 < len(items) {
+			next = []byte(strconv.Itoa(i + 1))
+		}
+		return items[i], next, nil
+	}, &fetched
+}
+
+func ExampleNewPageIterator() {
+	fetch := func(ctx context.Context, token []byte) ([]string, []byte, error) {
+		if len(token) == 0 {
+			return []string{"a", "b"}, []byte("next"), nil
+		}
+		return []string{"c"}, nil, nil
+	}
+
+	items, err := iterator.Collect(context.Background(), iterator.NewPageIterator(fetch, nil))
+	fmt.Println(items, err)
+	// Output:
+	// [a b c] <nil>
+}
+
+func TestNewPageIterator(t *testing.T) {
+	fetch, fetched := pages([]string{"a", "b"}, nil, []string{"c"}, []string{"d"})
+
+	items, err := iterator.Collect(context.Background(), iterator.NewPageIterator(fetch, &iterator.PageOptions{Prefetch: 2}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, items)
+	assert.Equal(t, int32(4), atomic.LoadInt32(fetched))
+}
+
+func TestNewPageIterator_Error(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	fetch := func(ctx context.Context, token []byte) ([]int, []byte, error) {
+		if len(token) == 0 {
+			return []int{1}, []byte("next"), nil
+		}
+		return nil, nil, errFetch
+	}
+
+	items, err := iterator.Collect(context.Background(), iterator.NewPageIterator(fetch, nil))
+	assert.ErrorIs(t, err, errFetch)
+	assert.Equal(t, []int{1}, items)
+}
+
+func TestNewPageIterator_ErrorWithItems(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	fetch := func(ctx context.Context, token []byte) ([]int, []byte, error) {
+		if len(token) == 0 {
+			return []int{1}, []byte("next"), nil
+		}
+		return []int{2, 3}, nil, errFetch
+	}
+
+	items, err := iterator.Collect(context.Background(), iterator.NewPageIterator(fetch, nil))
+	assert.ErrorIs(t, err, errFetch)
+	assert.Equal(t, []int{1, 2, 3}, items)
+}
+
+func TestNewPageIterator_BoundedPrefetch(t *testing.T) {
+	var pageItems [][]string
+	for i := 0; i < 10; i++ {
+		pageItems = append(pageItems, []string{strconv.Itoa(i)})
+	}
+	fetch, fetched := pages(pageItems...)
+
+	iter := iterator.NewPageIterator(fetch, nil)
+	assert.True(t, iter.Next(context.Background()))
+	time.Sleep(50 * time.Millisecond)
+
+	// the page being read, one buffered page and one waiting to be buffered
+	assert.LessOrEqual(t, atomic.LoadInt32(fetched), int32(3))
+	assert.NoError(t, iter.Close())
+}
+
+func TestNewPageIterator_Cancel(t *testing.T) {
+	fetch := func(ctx context.Context, token []byte) ([]int, []byte, error) {
+		if len(token) == 0 {
+			return []int{1}, []byte("next"), nil
+		}
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := iterator.NewPageIterator(fetch, nil)
+
+	assert.True(t, iter.Next(ctx))
+	cancel()
+	assert.False(t, iter.Next(ctx))
+	assert.ErrorIs(t, iter.Err(), context.Canceled)
+	assert.NoError(t, iter.Close())
+}
+
+func TestNewPageIterator_CloseUnstarted(t *testing.T) {
+	fetch, fetched := pages([]string{"a"})
+
+	assert.NoError(t, iterator.NewPageIterator(fetch, nil).Close())
+	assert.Equal(t, int32(0), atomic.LoadInt32(fetched))
+}
@@ -29,6 +29,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/urfave/cli/v2"
 )
 
@@ -48,14 +49,24 @@ func PrintCards(c *cli.Context, w io.Writer, items []interface{}, opts *PrintOpt
 		return fmt.Errorf("unable to print card view: %w", err)
 	}
 
-	for _, obj := range valuesList {
+	enableColor := color.Enabled(c, w)
+
+	for i, obj := range valuesList {
 		var rows []*cardColumns
+		var roles [][]color.Role
+		if opts.changes != nil {
+			rows = append(rows, &cardColumns{Name: changeField, Value: string(opts.changes[i])})
+			roles = append(roles, []color.Role{"", changeRoles[opts.changes[i]]})
+		}
 
 		for j, fieldValue := range obj {
 			rows = append(rows, &cardColumns{
-				Name:  fields[j],
+				Name:  fieldLabel(fields[j]),
 				Value: fieldValue,
 			})
+			if enableColor {
+				roles = append(roles, []color.Role{"", valueRole(opts, fields[j], fieldValue, formatField(c, fieldValue))})
+			}
 		}
 
 		var rowsI []interface{}
@@ -63,14 +74,25 @@ func PrintCards(c *cli.Context, w io.Writer, items []interface{}, opts *PrintOpt
 			rowsI = append(rowsI, row)
 		}
 
-		opts.NoHeader = true
-		opts.Fields = []string{"Name", "Value"}
-		err = PrintTable(c, w, rowsI, opts)
+		cardOpts := *opts
+		cardOpts.NoHeader = true
+		cardOpts.Fields = []string{"Name", "Value"}
+		cardOpts.layout = nil
+		cardOpts.changes = nil
+		cardOpts.columnRoles = map[string]color.Role{"Name": color.RoleKey}
+		cardOpts.cellRoles = roles
+		err = PrintTable(c, w, rowsI, &cardOpts)
 		if err != nil {
 			return err
 		}
 
-		fmt.Fprintln(w, strings.Repeat(opts.Separator, 10))
+		separator := strings.Repeat(opts.Separator, 10)
+		if separator != "" && color.Enabled(c, w) {
+			if theme, err := color.CurrentTheme(c); err == nil {
+				separator = theme.Sprintf(true, color.RoleMuted, "%s", separator)
+			}
+		}
+		fmt.Fprintln(w, separator)
 	}
 
 	return nil
@@ -0,0 +1,65 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"github.com/temporalio/tctl-kit/pkg/color"
+)
+
+// Colorizer returns the theme role to color a table or card value with, or "" to leave it uncolored
+type Colorizer func(field string, value interface{}) color.Role
+
+// changeRoles color the changes printed by Watch
+var changeRoles = map[WatchChange]color.Role{
+	Added:   color.RoleSuccess,
+	Updated: color.RoleWarning,
+	Removed: color.RoleError,
+}
+
+// valueRole returns the role of a field value from the colorizer, or from the color rules by the printed value
+func valueRole(opts *PrintOptions, field string, value interface{}, printed string) color.Role {
+	if opts.Colorizer != nil {
+		if role := opts.Colorizer(field, value); role != "" {
+			return role
+		}
+	}
+
+	return opts.ColorRules[field][printed]
+}
+
+// valueRoles returns the roles of the table cells, or nil if no values are colored
+func valueRoles(opts *PrintOptions, fields []string, rows [][]interface{}, cells [][]string) [][]color.Role {
+	if opts.Colorizer == nil && len(opts.ColorRules) == 0 {
+		return nil
+	}
+
+	roles := make([][]color.Role, len(rows))
+	for i, row := range rows {
+		roles[i] = make([]color.Role, len(row))
+		for j, value := range row {
+			roles[i][j] = valueRole(opts, fields[j], value, cells[i][j])
+		}
+	}
+
+	return roles
+}
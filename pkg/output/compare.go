@@ -0,0 +1,197 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+)
+
+// normalizeValue converts a field value to one of the comparable types:
+// nil, time.Time, time.Duration, float64, string or bool.
+// Other values are returned as is.
+func normalizeValue(i interface{}) interface{} {
+	switch v := i.(type) {
+	case *types.Timestamp:
+		if v == nil {
+			return nil
+		}
+		t, err := types.TimestampFromProto(v)
+		if err != nil {
+			return i
+		}
+		return t
+	case *types.Duration:
+		if v == nil {
+			return nil
+		}
+		d, err := types.DurationFromProto(v)
+		if err != nil {
+			return i
+		}
+		return d
+	case time.Time, time.Duration:
+		return v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *time.Duration:
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+
+	val := reflect.ValueOf(i)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	if !val.IsValid() {
+		return nil
+	}
+
+	if _, ok := val.Interface().(fmt.Stringer); ok {
+		// enums are compared by their names
+		return fmt.Sprint(val.Interface())
+	}
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return val.Float()
+	case reflect.String:
+		return val.String()
+	case reflect.Bool:
+		return val.Bool()
+	}
+
+	return val.Interface()
+}
+
+// compareValues compares two field values by their type: times, durations, numbers, strings and booleans.
+// Nil values are ordered first. A string compared with a number is parsed as a number. Other values of
+// different types are compared by their string representation if one of them is a string, otherwise
+// an error is returned.
+func compareValues(a, b interface{}) (int, error) {
+	a, b = normalizeValue(a), normalizeValue(b)
+
+	var err error
+	if a, err = parseNumber(a, b); err != nil {
+		return 0, err
+	}
+	if b, err = parseNumber(b, a); err != nil {
+		return 0, err
+	}
+
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0, nil
+		case a == nil:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	}
+
+	switch x := a.(type) {
+	case time.Time:
+		if y, ok := b.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1, nil
+			case x.After(y):
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case time.Duration:
+		if y, ok := b.(time.Duration); ok {
+			return compareOrdered(x, y), nil
+		}
+	case float64:
+		if y, ok := b.(float64); ok {
+			return compareOrdered(x, y), nil
+		}
+	case string:
+		return strings.Compare(x, fmt.Sprint(b)), nil
+	case bool:
+		if y, ok := b.(bool); ok {
+			return compareOrdered(boolToInt(x), boolToInt(y)), nil
+		}
+	}
+
+	if y, ok := b.(string); ok {
+		return strings.Compare(fmt.Sprint(a), y), nil
+	}
+
+	return 0, fmt.Errorf("unable to compare %T with %T", a, b)
+}
+
+// parseNumber parses the string value compared with a number, so "10" > 9 compares the numbers
+func parseNumber(value, other interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	if _, ok := other.(float64); !ok {
+		return value, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compare %q with number %v", s, other)
+	}
+	return f, nil
+}
+
+func compareOrdered[T int | float64 | time.Duration](x, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
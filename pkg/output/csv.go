@@ -0,0 +1,152 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// PrintCSV prints items as comma separated values following RFC 4180.
+// Nested structs are flattened into columns named by their dotted field paths.
+func PrintCSV(c *cli.Context, w io.Writer, items []interface{}, opts *PrintOptions) error {
+	return printDelimited(c, w, items, opts, ',')
+}
+
+// PrintTSV prints items as tab separated values, quoting values the same way as PrintCSV.
+func PrintTSV(c *cli.Context, w io.Writer, items []interface{}, opts *PrintOptions) error {
+	return printDelimited(c, w, items, opts, '\t')
+}
+
+func printDelimited(c *cli.Context, w io.Writer, items []interface{}, opts *PrintOptions, comma rune) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = extractFieldNames(items[0], []string{}, "", 1)
+	}
+
+	fields, err := flattenFields(items[0], fields)
+	if err != nil {
+		return fmt.Errorf("unable to print %v: %w", delimitedName(comma), err)
+	}
+
+	rows, err := extractFieldValues(items, fields)
+	if err != nil {
+		return fmt.Errorf("unable to print %v: %w", delimitedName(comma), err)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	if !opts.NoHeader {
+		header := make([]string, len(fields))
+		for i, f := range fields {
+			header[i] = fieldLabel(f)
+		}
+		if opts.changes != nil {
+			header = append([]string{changeField}, header...)
+		}
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for i, row := range rows {
+		columns := make([]string, len(row))
+		for j, column := range row {
+			columns[j] = formatField(c, column)
+		}
+		if opts.changes != nil {
+			columns = append([]string{string(opts.changes[i])}, columns...)
+		}
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// flattenFields replaces the fields holding structs with the dotted paths of their nested fields
+func flattenFields(item interface{}, fields []string) ([]string, error) {
+	values, err := extractFieldValues([]interface{}{item}, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var flat []string
+	for i, field := range fields {
+		value := values[0][i]
+		if !isNestedStruct(value) {
+			flat = append(flat, field)
+			continue
+		}
+
+		path, err := parseFieldPath(field)
+		if err != nil {
+			return nil, err
+		}
+
+		nested := extractFieldNames(value, []string{}, "", fieldsDepth)
+		for j, name := range nested {
+			isParent := j+1 < len(nested) && strings.HasPrefix(nested[j+1], name+".")
+			if isParent {
+				continue
+			}
+
+			if path.name != "" {
+				flat = append(flat, path.name+"."+name+"="+path.expr+"."+name)
+			} else {
+				flat = append(flat, path.expr+"."+name)
+			}
+		}
+	}
+
+	return flat, nil
+}
+
+func isNestedStruct(i interface{}) bool {
+	val := reflect.Indirect(reflect.ValueOf(i))
+	if !val.IsValid() || val.Kind() != reflect.Struct || val.NumField() == 0 {
+		return false
+	}
+
+	return val.Type() != reflect.TypeOf(time.Time{})
+}
+
+func delimitedName(comma rune) string {
+	if comma == '\t' {
+		return "tsv"
+	}
+	return "csv"
+}
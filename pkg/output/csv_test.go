@@ -0,0 +1,76 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"os"
+
+	"github.com/temporalio/tctl-kit/pkg/output"
+)
+
+func ExamplePrintCSV() {
+	ctx, teardown := setupPrinterTest()
+	defer teardown()
+
+	items := newPrinterItems(2)
+	items[1].(*dataForPrinter).Name = "foo, \"quoted\"\nvalue"
+
+	output.PrintCSV(ctx, os.Stdout, items, &output.PrintOptions{})
+
+	// Output:
+	// Name,Value,Nested.NName
+	// foo,1,bar
+	// "foo, ""quoted""
+	// value",2,bar
+}
+
+func ExamplePrintTSV() {
+	ctx, teardown := setupPrinterTest()
+	defer teardown()
+
+	po := &output.PrintOptions{
+		Fields: []string{"Nested", "Value"},
+	}
+
+	output.PrintTSV(ctx, os.Stdout, newPrinterItems(2), po)
+
+	// Output:
+	// Nested.NName	Value
+	// bar	1
+	// bar	2
+}
+
+func ExamplePrintIterator_csv() {
+	ctx, teardown := setupPrinterTest("--output", "csv", "--follow")
+	defer teardown()
+
+	iter := &sliceIterator{items: newPrinterItems(3)}
+
+	output.PrintIterator(ctx, iter, &output.PrintOptions{Fields: []string{"Name", "Value"}})
+
+	// Output:
+	// Name,Value
+	// foo,1
+	// foo,2
+	// foo,3
+}
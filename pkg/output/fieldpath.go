@@ -0,0 +1,357 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fieldPath is a parsed --fields expression. Besides the field names separated by dots,
+// it supports slice indexes ([0], [-1]), map keys (["key"]) and wildcards ([*]).
+// An expression may be prefixed with a column name, ex. "Id=Execution.WorkflowId".
+type fieldPath struct {
+	// name is the optional column name
+	name string
+	// expr is the path expression
+	expr string
+	// last is the start of the last dotted component of expr
+	last     int
+	segments []pathSegment
+}
+
+type segmentKind int
+
+const (
+	segmentField segmentKind = iota
+	segmentIndex
+	segmentKey
+	segmentWildcard
+)
+
+type pathSegment struct {
+	kind  segmentKind
+	name  string
+	index int
+}
+
+var columnNamePattern = regexp.MustCompile(`^([A-Za-z_][\w\-.]*)\s*=\s*([^=].*)$`)
+
+func parseFieldPath(field string) (*fieldPath, error) {
+	path := &fieldPath{expr: strings.TrimSpace(field)}
+	if m := columnNamePattern.FindStringSubmatch(path.expr); m != nil {
+		path.name = m[1]
+		path.expr = strings.TrimSpace(m[2])
+	}
+
+	expr := path.expr
+	for i := 0; i < len(expr); {
+		switch {
+		case expr[i] == '.' || (i == 0 && isNameChar(expr[i])):
+			if expr[i] == '.' {
+				i++
+			}
+			end := i
+			for end < len(expr) && isNameChar(expr[end]) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("invalid field %v: expected field name at position %v", expr, i)
+			}
+			path.segments = append(path.segments, pathSegment{kind: segmentField, name: expr[i:end]})
+			path.last = i
+			i = end
+		case expr[i] == '[':
+			end := closingBracket(expr, i)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid field %v: missing closing bracket", expr)
+			}
+			segment, err := parseBracket(expr[i+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid field %v: %w", expr, err)
+			}
+			path.segments = append(path.segments, segment)
+			i = end + 1
+		default:
+			return nil, fmt.Errorf("invalid field %v: unexpected character %q at position %v", expr, expr[i], i)
+		}
+	}
+
+	if len(path.segments) == 0 {
+		return nil, fmt.Errorf("empty field")
+	}
+
+	return path, nil
+}
+
+func parseFieldPaths(fields []string) ([]*fieldPath, error) {
+	paths := make([]*fieldPath, len(fields))
+	for i, f := range fields {
+		path, err := parseFieldPath(f)
+		if err != nil {
+			return nil, err
+		}
+		paths[i] = path
+	}
+
+	return paths, nil
+}
+
+func parseBracket(inner string) (pathSegment, error) {
+	inner = strings.TrimSpace(inner)
+
+	switch {
+	case inner == "*":
+		return pathSegment{kind: segmentWildcard}, nil
+	case strings.HasPrefix(inner, `"`):
+		key, err := strconv.Unquote(inner)
+		if err != nil {
+			return pathSegment{}, fmt.Errorf("invalid key %v", inner)
+		}
+		return pathSegment{kind: segmentKey, name: key}, nil
+	case strings.HasPrefix(inner, "'") && strings.HasSuffix(inner, "'") && len(inner) > 1:
+		return pathSegment{kind: segmentKey, name: inner[1 : len(inner)-1]}, nil
+	default:
+		index, err := strconv.Atoi(inner)
+		if err != nil {
+			return pathSegment{}, fmt.Errorf("invalid index %v", inner)
+		}
+		return pathSegment{kind: segmentIndex, index: index}, nil
+	}
+}
+
+// label returns the column name, or the full path expression if there is none.
+// Used where the full path is needed to tell the fields apart (JSON keys, CSV headers..)
+func (p *fieldPath) label() string {
+	if p.name != "" {
+		return p.name
+	}
+	return p.expr
+}
+
+// header returns the column name, or the last component of the path expression if there is none.
+func (p *fieldPath) header() string {
+	if p.name != "" {
+		return p.name
+	}
+	return p.expr[p.last:]
+}
+
+// fieldLabel returns the label of a field expression, or the expression itself if it's invalid
+func fieldLabel(field string) string {
+	if path, err := parseFieldPath(field); err == nil {
+		return path.label()
+	}
+	return field
+}
+
+// fieldHeader returns the header of a field expression, or the expression itself if it's invalid
+func fieldHeader(field string) string {
+	if path, err := parseFieldPath(field); err == nil {
+		return path.header()
+	}
+	return field
+}
+
+// validate checks that the path can be resolved on values of the given type
+func (p *fieldPath) validate(typ reflect.Type) error {
+	for _, seg := range p.segments {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		if typ.Kind() == reflect.Interface {
+			// the type is only known at runtime
+			return nil
+		}
+
+		switch seg.kind {
+		case segmentField, segmentKey:
+			switch typ.Kind() {
+			case reflect.Struct:
+				f, ok := typ.FieldByName(seg.name)
+				if !ok || !isFieldExported(f) {
+					return fmt.Errorf("unknown field %v", p.expr)
+				}
+				typ = f.Type
+			case reflect.Map:
+				if typ.Key().Kind() != reflect.String {
+					return fmt.Errorf("invalid field %v: %v has non-string keys", p.expr, typ)
+				}
+				typ = typ.Elem()
+			default:
+				return fmt.Errorf("unknown field %v", p.expr)
+			}
+		case segmentIndex:
+			if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+				return fmt.Errorf("invalid field %v: %v can't be indexed", p.expr, typ)
+			}
+			typ = typ.Elem()
+		case segmentWildcard:
+			if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array && typ.Kind() != reflect.Map {
+				return fmt.Errorf("invalid field %v: %v can't be iterated", p.expr, typ)
+			}
+			typ = typ.Elem()
+		}
+	}
+
+	return nil
+}
+
+// eval returns the value at the path, or nil if the path can't be resolved (ex. nil pointers,
+// missing map keys, out of range indexes). Wildcards produce a slice of values.
+func (p *fieldPath) eval(obj interface{}) interface{} {
+	return evalSegments(reflect.ValueOf(obj), p.segments)
+}
+
+func evalSegments(val reflect.Value, segments []pathSegment) interface{} {
+	for i, seg := range segments {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return nil
+			}
+			val = val.Elem()
+		}
+
+		if !val.IsValid() {
+			return nil
+		}
+
+		switch seg.kind {
+		case segmentField, segmentKey:
+			switch val.Kind() {
+			case reflect.Struct:
+				val = val.FieldByName(seg.name)
+			case reflect.Map:
+				if val.Type().Key().Kind() != reflect.String {
+					return nil
+				}
+				val = val.MapIndex(reflect.ValueOf(seg.name).Convert(val.Type().Key()))
+			default:
+				return nil
+			}
+		case segmentIndex:
+			if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+				return nil
+			}
+			index := seg.index
+			if index < 0 {
+				index += val.Len()
+			}
+			if index < 0 || index >= val.Len() {
+				return nil
+			}
+			val = val.Index(index)
+		case segmentWildcard:
+			var elems []reflect.Value
+			switch val.Kind() {
+			case reflect.Slice, reflect.Array:
+				for j := 0; j < val.Len(); j++ {
+					elems = append(elems, val.Index(j))
+				}
+			case reflect.Map:
+				keys := val.MapKeys()
+				sort.Slice(keys, func(a, b int) bool {
+					return fmt.Sprint(keys[a].Interface()) < fmt.Sprint(keys[b].Interface())
+				})
+				for _, key := range keys {
+					elems = append(elems, val.MapIndex(key))
+				}
+			default:
+				return nil
+			}
+
+			results := make([]interface{}, len(elems))
+			for j, elem := range elems {
+				results[j] = evalSegments(elem, segments[i+1:])
+			}
+			return results
+		}
+	}
+
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
+
+	return val.Interface()
+}
+
+// splitFields splits a comma separated list of field expressions,
+// ignoring the commas inside of brackets and quotes.
+func splitFields(fields string) []string {
+	var result []string
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(fields); i++ {
+		ch := fields[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']':
+			depth--
+		case ch == ',' && depth == 0:
+			result = append(result, strings.TrimSpace(fields[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(result, strings.TrimSpace(fields[start:]))
+}
+
+func closingBracket(expr string, open int) int {
+	var quote byte
+	for i := open + 1; i < len(expr); i++ {
+		ch := expr[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == ']':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isNameChar(ch byte) bool {
+	return ch == '_' || ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') || ('0' <= ch && ch <= '9')
+}
@@ -0,0 +1,153 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/output"
+)
+
+type dataForFieldPath struct {
+	Name      string
+	Execution *struct {
+		WorkflowId string
+		Memo       map[string]string
+	}
+	Tags []string
+}
+
+func newFieldPathItem() *dataForFieldPath {
+	item := &dataForFieldPath{
+		Name: "foo",
+		Tags: []string{"a", "b", "c"},
+	}
+	item.Execution = &struct {
+		WorkflowId string
+		Memo       map[string]string
+	}{
+		WorkflowId: "wid",
+		Memo:       map[string]string{"x": "v1", "y,z": "v2"},
+	}
+
+	return item
+}
+
+func TestFieldPaths(t *testing.T) {
+	testcases := map[string]struct {
+		fields string
+		expect string
+		err    string
+	}{
+		"nested field": {
+			fields: "Name,Execution.WorkflowId",
+			expect: `{"Name":"foo","Execution.WorkflowId":"wid"}`,
+		},
+		"leading dot": {
+			fields: ".Execution.WorkflowId",
+			expect: `{".Execution.WorkflowId":"wid"}`,
+		},
+		"column name": {
+			fields: "Id=Execution.WorkflowId",
+			expect: `{"Id":"wid"}`,
+		},
+		"map key": {
+			fields: `Execution.Memo["x"], Execution.Memo['y,z'], Execution.Memo.x`,
+			expect: `{"Execution.Memo[\"x\"]":"v1","Execution.Memo['y,z']":"v2","Execution.Memo.x":"v1"}`,
+		},
+		"missing map key": {
+			fields: `Execution.Memo["missing"]`,
+			expect: `{"Execution.Memo[\"missing\"]":null}`,
+		},
+		"index": {
+			fields: "First=Tags[0],Last=Tags[-1],None=Tags[5]",
+			expect: `{"First":"a","Last":"c","None":null}`,
+		},
+		"wildcard": {
+			fields: "Tags[*],Memo=Execution.Memo[*]",
+			expect: `{"Tags[*]":["a","b","c"],"Memo":["v1","v2"]}`,
+		},
+		"unknown field": {
+			fields: "Execution.RunId",
+			err:    "unknown field Execution.RunId.\nAvailable fields: \"Name\",\"Execution\",\"Execution.WorkflowId\",\"Execution.Memo\",\"Tags\"",
+		},
+		"invalid index": {
+			fields: "Name[0]",
+			err:    "invalid field Name[0]: string can't be indexed",
+		},
+		"invalid syntax": {
+			fields: "Tags[0",
+			err:    "invalid field Tags[0: missing closing bracket",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, teardown := setupPrinterTest("--output", "jsonl", "--fields", tc.fields)
+			defer teardown()
+
+			var buf bytes.Buffer
+			ctx.App.Writer = &buf
+
+			err := output.PrintItems(ctx, []interface{}{newFieldPathItem()}, &output.PrintOptions{})
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expect+"\n", buf.String())
+			}
+		})
+	}
+}
+
+func ExamplePrintTable_fieldPaths() {
+	ctx, teardown := setupPrinterTest()
+	defer teardown()
+
+	po := output.PrintOptions{
+		Fields:   []string{"Name", "Execution.WorkflowId", `Execution.Memo["x"]`, "Tag=Tags[1]"},
+		NoHeader: true,
+	}
+
+	output.PrintTable(ctx, os.Stdout, []interface{}{newFieldPathItem()}, &po)
+
+	// Output:
+	// foo  wid  v1  b
+}
+
+func TestFieldPathHeaders(t *testing.T) {
+	ctx, teardown := setupPrinterTest()
+	defer teardown()
+
+	po := output.PrintOptions{
+		Fields: []string{"Execution.WorkflowId", `Execution.Memo["x"]`, "Tag=Tags[1]"},
+	}
+
+	var buf bytes.Buffer
+	err := output.PrintTable(ctx, &buf, []interface{}{newFieldPathItem()}, &po)
+	assert.NoError(t, err)
+	assert.Regexp(t, `WorkflowId\s+Memo\["x"\]\s+Tag`, buf.String())
+}
@@ -0,0 +1,511 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// itemFilter is a parsed --filter expression, ex. `Status == "Running" && StartTime > -1h`.
+//
+// Operands are field paths (same as in --fields), "strings", numbers, durations, true, false and null.
+// A duration compared with a time is relative to the current time.
+// Comparisons: ==, !=, <, <=, >, >=, =~ (matches regex), !~ (doesn't match regex).
+// Logical operators: &&, ||, ! and parentheses.
+// A comparison with a wildcard path (ex. Tags[*] == "foo") is true if any of the values match.
+type itemFilter struct {
+	expr      string
+	root      filterNode
+	paths     []*fieldPath
+	validated bool
+}
+
+// newItemFilter returns the filter provided with the --filter flag, or nil if there is none.
+func newItemFilter(c *cli.Context) (*itemFilter, error) {
+	expr := strings.TrimSpace(c.String(FlagFilter))
+	if expr == "" {
+		return nil, nil
+	}
+
+	return parseFilter(expr)
+}
+
+// match reports whether the item satisfies the filter
+func (f *itemFilter) match(item interface{}) (bool, error) {
+	if !f.validated {
+		if err := validateFields(item, f.paths); err != nil {
+			return false, fmt.Errorf("invalid filter %v: %w", f.expr, err)
+		}
+		f.validated = true
+	}
+
+	ok, err := f.root.eval(item)
+	if err != nil {
+		return false, fmt.Errorf("unable to apply filter %v: %w", f.expr, err)
+	}
+
+	return ok, nil
+}
+
+// filterItems returns the items that satisfy the --filter flag
+func filterItems(c *cli.Context, items []interface{}) ([]interface{}, error) {
+	filter, err := newItemFilter(c)
+	if err != nil || filter == nil {
+		return items, err
+	}
+
+	var result []interface{}
+	for _, item := range items {
+		ok, err := filter.match(item)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+type filterNode interface {
+	eval(item interface{}) (bool, error)
+}
+
+type andNode struct{ left, right filterNode }
+
+type orNode struct{ left, right filterNode }
+
+type notNode struct{ node filterNode }
+
+type comparisonNode struct {
+	op    string
+	left  filterOperand
+	right filterOperand
+	regex *regexp.Regexp
+}
+
+// truthNode is an operand used without a comparison, ex. `IsCron && Status == "Running"`
+type truthNode struct{ operand filterOperand }
+
+type filterOperand struct {
+	path    *fieldPath
+	literal interface{}
+}
+
+func (n *andNode) eval(item interface{}) (bool, error) {
+	ok, err := n.left.eval(item)
+	if err != nil || !ok {
+		return false, err
+	}
+	return n.right.eval(item)
+}
+
+func (n *orNode) eval(item interface{}) (bool, error) {
+	ok, err := n.left.eval(item)
+	if err != nil || ok {
+		return ok, err
+	}
+	return n.right.eval(item)
+}
+
+func (n *notNode) eval(item interface{}) (bool, error) {
+	ok, err := n.node.eval(item)
+	return !ok, err
+}
+
+func (n *truthNode) eval(item interface{}) (bool, error) {
+	switch v := normalizeValue(n.operand.value(item)).(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		return v != "", nil
+	case float64:
+		return v != 0, nil
+	case []interface{}:
+		return len(v) > 0, nil
+	default:
+		return true, nil
+	}
+}
+
+func (n *comparisonNode) eval(item interface{}) (bool, error) {
+	left := n.left.value(item)
+	right := n.right.value(item)
+
+	// wildcard paths match if any of the values match
+	if values, ok := left.([]interface{}); ok && n.left.path != nil {
+		for _, v := range values {
+			if ok, err := n.compare(v, right); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+
+	return n.compare(left, right)
+}
+
+func (n *comparisonNode) compare(left, right interface{}) (bool, error) {
+	if n.regex != nil {
+		matched := n.regex.MatchString(fmt.Sprint(normalizeValue(left)))
+		return matched == (n.op == "=~"), nil
+	}
+
+	left, err := resolveLiteral(left, right, n.left.path == nil)
+	if err != nil {
+		return false, err
+	}
+	right, err = resolveLiteral(right, left, n.right.path == nil)
+	if err != nil {
+		return false, err
+	}
+
+	cmp, err := compareValues(left, right)
+	if err != nil {
+		return false, err
+	}
+
+	switch n.op {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+
+	return false, fmt.Errorf("unknown operator %v", n.op)
+}
+
+// resolveLiteral converts a literal compared with a time: durations become relative to now
+// and strings are parsed as RFC 3339 times or dates
+func resolveLiteral(value, other interface{}, isLiteral bool) (interface{}, error) {
+	if !isLiteral {
+		return value, nil
+	}
+
+	if _, ok := normalizeValue(other).(time.Time); !ok {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case time.Duration:
+		return time.Now().Add(v), nil
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid time %q, expected RFC 3339 time, date or duration", v)
+	}
+
+	return value, nil
+}
+
+func (o filterOperand) value(item interface{}) interface{} {
+	if o.path != nil {
+		return o.path.eval(item)
+	}
+	return o.literal
+}
+
+func parseFilter(expr string) (*itemFilter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %v: %w", expr, err)
+	}
+
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %v", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %v: %w", expr, err)
+	}
+
+	return &itemFilter{expr: expr, root: root, paths: p.paths}, nil
+}
+
+type tokenKind int
+
+const (
+	tokenOperator tokenKind = iota
+	tokenPath
+	tokenLiteral
+)
+
+type filterToken struct {
+	kind    tokenKind
+	text    string
+	literal interface{}
+}
+
+var filterOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "(", ")"}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+
+		if ch == ' ' || ch == '\t' || ch == '\n' {
+			i++
+			continue
+		}
+
+		if op := matchOperator(expr[i:]); op != "" {
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: op})
+			i += len(op)
+			continue
+		}
+
+		switch {
+		case ch == '"' || ch == '\'':
+			end := i + 1
+			for end < len(expr) && expr[end] != ch {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %v", i)
+			}
+
+			text := expr[i : end+1]
+			str := text[1 : len(text)-1]
+			if ch == '"' {
+				var err error
+				if str, err = strconv.Unquote(text); err != nil {
+					return nil, fmt.Errorf("invalid string %v", text)
+				}
+			}
+			tokens = append(tokens, filterToken{kind: tokenLiteral, text: text, literal: str})
+			i = end + 1
+		case isDigit(ch) || ((ch == '-' || ch == '+' || ch == '.') && i+1 < len(expr) && isDigit(expr[i+1])):
+			end := i + 1
+			for end < len(expr) && (isNameChar(expr[end]) || expr[end] == '.') {
+				end++
+			}
+
+			text := expr[i:end]
+			if n, err := strconv.ParseFloat(text, 64); err == nil {
+				tokens = append(tokens, filterToken{kind: tokenLiteral, text: text, literal: n})
+			} else if d, err := time.ParseDuration(text); err == nil {
+				tokens = append(tokens, filterToken{kind: tokenLiteral, text: text, literal: d})
+			} else {
+				return nil, fmt.Errorf("invalid number or duration %v", text)
+			}
+			i = end
+		case isNameChar(ch) || ch == '.':
+			end := i
+			for end < len(expr) && (isNameChar(expr[end]) || expr[end] == '.' || expr[end] == '[') {
+				if expr[end] == '[' {
+					if end = closingBracket(expr, end); end < 0 {
+						return nil, fmt.Errorf("missing closing bracket at position %v", i)
+					}
+				}
+				end++
+			}
+
+			text := expr[i:end]
+			switch text {
+			case "true", "false":
+				tokens = append(tokens, filterToken{kind: tokenLiteral, text: text, literal: text == "true"})
+			case "null":
+				tokens = append(tokens, filterToken{kind: tokenLiteral, text: text, literal: nil})
+			default:
+				tokens = append(tokens, filterToken{kind: tokenPath, text: text})
+			}
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %v", ch, i)
+		}
+	}
+
+	return tokens, nil
+}
+
+func matchOperator(s string) string {
+	for _, op := range filterOperators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	paths  []*fieldPath
+}
+
+func (p *filterParser) peekOperator(ops ...string) string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOperator {
+		return ""
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekOperator("||") != "" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekOperator("&&") != "" {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.peekOperator("!") != "" {
+		p.pos++
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{node: node}, nil
+	}
+
+	if p.peekOperator("(") != "" {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekOperator(")") == "" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peekOperator("==", "!=", "<=", ">=", "=~", "!~", "<", ">")
+	if op == "" {
+		return &truthNode{operand: left}, nil
+	}
+	p.pos++
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &comparisonNode{op: op, left: left, right: right}
+	if op == "=~" || op == "!~" {
+		pattern, ok := right.literal.(string)
+		if !ok || right.path != nil {
+			return nil, fmt.Errorf("%v expects a regular expression string", op)
+		}
+		if node.regex, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %v: %w", pattern, err)
+		}
+	}
+
+	return node, nil
+}
+
+func (p *filterParser) parseOperand() (filterOperand, error) {
+	if p.pos >= len(p.tokens) {
+		return filterOperand{}, fmt.Errorf("unexpected end of expression")
+	}
+
+	token := p.tokens[p.pos]
+	switch token.kind {
+	case tokenPath:
+		p.pos++
+		path, err := parseFieldPath(token.text)
+		if err != nil {
+			return filterOperand{}, err
+		}
+		p.paths = append(p.paths, path)
+		return filterOperand{path: path}, nil
+	case tokenLiteral:
+		p.pos++
+		return filterOperand{literal: token.literal}, nil
+	}
+
+	return filterOperand{}, fmt.Errorf("unexpected %v", token.text)
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
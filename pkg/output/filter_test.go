@@ -0,0 +1,161 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/output"
+)
+
+type filterStatus int32
+
+func (s filterStatus) String() string {
+	return [...]string{"Unspecified", "Running", "Failed"}[s]
+}
+
+type dataForFilter struct {
+	Name      string
+	Status    filterStatus
+	StartTime *time.Time
+	Timeout   time.Duration
+	Attempt   int
+	IsCron    bool
+	Tags      []string
+	Revision  string
+}
+
+func newFilterItems() []interface{} {
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	dayAgo := now.Add(-24 * time.Hour)
+
+	return []interface{}{
+		&dataForFilter{Name: "a", Status: 1, StartTime: &now, Timeout: time.Minute, Attempt: 1, Tags: []string{"x"}, Revision: "10"},
+		&dataForFilter{Name: "b", Status: 2, StartTime: &hourAgo, Timeout: time.Hour, Attempt: 3, IsCron: true, Revision: "9"},
+		&dataForFilter{Name: "c", Status: 1, StartTime: &dayAgo, Attempt: 10, Tags: []string{"y", "z"}, Revision: "100"},
+		&dataForFilter{Name: "d", Revision: "0"},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	testcases := map[string]struct {
+		filter string
+		expect string
+		err    string
+	}{
+		"enum by name": {
+			filter: `Status == "Running"`,
+			expect: "a,c",
+		},
+		"relative time": {
+			filter: `StartTime > -2h`,
+			expect: "a,b",
+		},
+		"absolute time": {
+			filter: `StartTime < "2000-01-01" || StartTime == null`,
+			expect: "d",
+		},
+		"numbers": {
+			filter: `Attempt >= 3 && Attempt < 10`,
+			expect: "b",
+		},
+		"numeric strings": {
+			filter: `Revision > 9`,
+			expect: "a,c",
+		},
+		"string and number": {
+			filter: `Name > 9`,
+			err:    `unable to compare "a" with number 9`,
+		},
+		"durations": {
+			filter: `Timeout > 30s`,
+			expect: "a,b",
+		},
+		"boolean and negation": {
+			filter: `!IsCron && Name != 'd'`,
+			expect: "a,c",
+		},
+		"parentheses": {
+			filter: `(Name == "a" || Name == "b") && Status == "Failed"`,
+			expect: "b",
+		},
+		"regex": {
+			filter: `Name =~ "^[ab]$"`,
+			expect: "a,b",
+		},
+		"wildcard": {
+			filter: `Tags[*] == "z"`,
+			expect: "c",
+		},
+		"unknown field": {
+			filter: `State == "Running"`,
+			err:    "unknown field State.\nAvailable fields: \"Name\",\"Status\",\"StartTime\",\"Timeout\",\"Attempt\",\"IsCron\",\"Tags\",\"Revision\"",
+		},
+		"syntax error": {
+			filter: `Name == `,
+			err:    "invalid filter Name ==: unexpected end of expression",
+		},
+		"invalid time": {
+			filter: `StartTime > "yesterday"`,
+			err:    `invalid time "yesterday"`,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, teardown := setupPrinterTest("--output", "template", "--template", "{{.Name}}", "--filter", tc.filter)
+			defer teardown()
+
+			var buf bytes.Buffer
+			ctx.App.Writer = &buf
+
+			err := output.PrintItems(ctx, newFilterItems(), &output.PrintOptions{})
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expect, strings.Join(strings.Fields(buf.String()), ","))
+			}
+		})
+	}
+}
+
+func TestFilterBeforeLimit(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--output", "template", "--template", "{{.Name}}", "--filter", `Status == "Running" || Status == "Failed"`, "--limit", "2")
+	defer teardown()
+
+	var buf bytes.Buffer
+	ctx.App.Writer = &buf
+
+	iter := &sliceIterator{items: newFilterItems()[1:]}
+	iter.items = append(iter.items, newFilterItems()...)
+
+	err := output.PrintIterator(ctx, iter, &output.PrintOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "b\nc\n", buf.String())
+}
@@ -32,6 +32,12 @@ const (
 	FlagLimit  = "limit"
 	FlagFollow = "follow"
 
+	FlagTemplate = "template"
+	FlagFilter   = "filter"
+	FlagSortBy   = "sort-by"
+	FlagWide     = "wide"
+	FlagOffset   = "offset"
+
 	FieldsLong = "long"
 )
 
@@ -41,8 +47,14 @@ const (
 	Table OutputOption = "table"
 	JSON  OutputOption = "json"
 	Card  OutputOption = "card"
+	YAML  OutputOption = "yaml"
+	JSONL OutputOption = "jsonl"
+	CSV   OutputOption = "csv"
+	TSV   OutputOption = "tsv"
+
+	Template OutputOption = "template"
 )
 
 var (
-	UsageText = fmt.Sprintf("format output as: %v, %v, %v.", Table, JSON, Card)
+	UsageText = fmt.Sprintf("format output as: %v, %v, %v, %v, %v, %v, %v, %v.", Table, JSON, Card, YAML, JSONL, CSV, TSV, Template)
 )
@@ -45,6 +45,30 @@ func PrintJSON(c *cli.Context, w io.Writer, o interface{}) error {
 	return err
 }
 
+// PrintJSONLines prints each item as a compact JSON object on a separate line.
+// If fields are provided, only those fields of the items are printed.
+func PrintJSONLines(c *cli.Context, w io.Writer, items []interface{}, fields []string) error {
+	if len(fields) > 0 {
+		var err error
+		if items, err = projectItems(items, fields); err != nil {
+			return fmt.Errorf("unable to print json lines: %w", err)
+		}
+	}
+
+	for _, item := range items {
+		json, err := ParseToJSON(item, false)
+		if err != nil {
+			return fmt.Errorf("unable to print json lines: %w", err)
+		}
+
+		if _, err = fmt.Fprintln(w, json); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func ParseToJSON(o interface{}, indent bool) (string, error) {
 	var b []byte
 	var err error
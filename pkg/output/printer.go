@@ -25,11 +25,16 @@
 package output
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
 	"reflect"
-	"strings"
 	"time"
 
+	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/temporalio/tctl-kit/pkg/format"
 	"github.com/temporalio/tctl-kit/pkg/iterator"
 	"github.com/temporalio/tctl-kit/pkg/pager"
@@ -37,6 +42,7 @@ import (
 )
 
 const (
+	// Deprecated: PrintOptions.PageSize defaults to pager.DefaultListPageSize
 	BatchPrintSize = 100
 )
 
@@ -55,89 +61,358 @@ type PrintOptions struct {
 	NoHeader bool
 	// Separator to use in table output
 	Separator string
+	// ColumnWidths sets the width of table columns by field. Longer values are truncated, unless --wide is set
+	ColumnWidths map[string]int
+	// MaxColumnWidths limits the width of table columns by field. Longer values are truncated, unless --wide is set
+	MaxColumnWidths map[string]int
+	// Wrap wraps long table values instead of truncating them
+	Wrap bool
+	// PageSize is the number of items PrintIterator prints at once. Default - pager.DefaultListPageSize
+	PageSize int
+	// ColorRules color table and card values by field, mapping the printed values to theme roles,
+	// ex. {"Status": {"Running": color.RoleSuccess, "Failed": color.RoleError}}. Used only when color is enabled
+	ColorRules map[string]map[string]color.Role
+	// Colorizer colors table and card values, taking precedence over ColorRules. Used only when color is enabled
+	Colorizer Colorizer
+	// SortBufferSize is the max number of items PrintIterator buffers to sort with --sort-by. Default - DefaultSortBufferSize
+	SortBufferSize int
+
+	// layout keeps the table columns aligned across PrintIterator batches
+	layout *tableLayout
+	// template is the --template parsed once for all PrintIterator batches
+	template *parsedTemplate
+	// changes marks the printed items in Watch, one per item
+	changes []WatchChange
+	// columnRoles colors the table columns by field
+	columnRoles map[string]color.Role
+	// cellRoles colors the table cells, instead of ColorRules and Colorizer
+	cellRoles [][]color.Role
+}
+
+type tableLayout struct {
+	widths []int
 }
 
 // PrintItems prints items based on user flags or print options.
 func PrintItems(c *cli.Context, items []interface{}, opts *PrintOptions) error {
-	fields := c.String(FlagFields)
-
-	pagerName := c.String(pager.FlagPager)
-	if pagerName == "" {
-		pagerName = string(opts.Pager)
+	items, err := filterItems(c, items)
+	if err != nil {
+		return err
 	}
 
-	writer, close := pager.NewPager(c, pagerName)
-	defer close()
+	items, err = sortItems(c, items)
+	if err != nil {
+		return err
+	}
 
-	if !opts.ForceFields && c.IsSet(FlagFields) {
-		if fields == FieldsLong {
-			opts.Fields = append(opts.Fields, opts.FieldsLong...)
-			opts.FieldsLong = []string{}
-		} else {
-			f := strings.Split(fields, ",")
-			for i := range f {
-				f[i] = strings.TrimSpace(f[i])
-			}
-			opts.Fields = f
-			opts.FieldsLong = []string{}
+	// the offset applies to the filtered and sorted items, as in PrintIterator
+	if offset := c.Int(FlagOffset); offset > 0 {
+		if offset > len(items) {
+			offset = len(items)
 		}
+		items = items[offset:]
+	}
+
+	return printItems(c, items, opts)
+}
+
+func printItems(c *cli.Context, items []interface{}, opts *PrintOptions) error {
+	return withPager(c, opts, func(w io.Writer) error {
+		return writeItems(c, w, items, opts)
+	})
+}
+
+// withPager calls print with the writer of the pager and closes the pager once done.
+// The user quitting the pager before everything is printed isn't an error.
+func withPager(c *cli.Context, opts *PrintOptions, print func(w io.Writer) error) error {
+	writer, close := pager.NewPager(c, pagerName(c, opts))
+
+	err := print(writer)
+	if closeErr := close(); err == nil || errors.Is(err, pager.ErrPagerClosed) {
+		err = closeErr
+	}
+
+	return err
+}
+
+// pagerName returns the pager set by the user, or the one in print options
+func pagerName(c *cli.Context, opts *PrintOptions) string {
+	return pager.Command(c, string(opts.Pager))
+}
+
+// writeItems prints items to the writer in the output format.
+// It returns the write errors that the printers don't report, such as the pager being closed.
+func writeItems(c *cli.Context, writer io.Writer, items []interface{}, opts *PrintOptions) error {
+	w := &errWriter{w: writer}
+	if err := formatItems(c, w, items, opts); err != nil {
+		return err
 	}
+	return w.err
+}
+
+// errWriter keeps the first write error and stops writing after it
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	n, err := e.w.Write(p)
+	e.err = err
+	return n, err
+}
+
+// IsTerminal tells whether colors can be printed to the underlying writer
+func (e *errWriter) IsTerminal() bool {
+	return color.IsTerminal(e.w)
+}
+
+func formatItems(c *cli.Context, writer io.Writer, items []interface{}, opts *PrintOptions) error {
+	selectedFields := selectFields(c, opts)
 
 	output := getOutputFormat(c, opts)
 	switch output {
 	case Table:
 		return PrintTable(c, writer, items, opts)
 	case JSON:
+		if len(selectedFields) > 0 {
+			projected, err := projectItems(items, selectedFields)
+			if err != nil {
+				return fmt.Errorf("unable to print json: %w", err)
+			}
+			return PrintJSON(c, writer, projected)
+		}
 		return PrintJSON(c, writer, items)
 	case Card:
 		return PrintCards(c, writer, items, opts)
+	case YAML:
+		return PrintYAML(c, writer, items, selectedFields)
+	case JSONL:
+		return PrintJSONLines(c, writer, items, selectedFields)
+	case CSV:
+		return PrintCSV(c, writer, items, opts)
+	case TSV:
+		return PrintTSV(c, writer, items, opts)
+	case Template:
+		return printTemplate(c, writer, items, opts)
 	}
 
 	return nil
 }
 
+// selectFields applies the user provided --fields to the print options.
+// It returns the fields selected by the user, or nil if none are selected.
+func selectFields(c *cli.Context, opts *PrintOptions) []string {
+	if opts.ForceFields || !c.IsSet(FlagFields) {
+		return nil
+	}
+
+	if fields := c.String(FlagFields); fields == FieldsLong {
+		opts.Fields = append(opts.Fields, opts.FieldsLong...)
+	} else {
+		opts.Fields = splitFields(fields)
+	}
+	opts.FieldsLong = []string{}
+
+	return opts.Fields
+}
+
 // PrintIterator prints items from an iterator based on user flags or print options.
 func PrintIterator(c *cli.Context, iter iterator.Iterator[interface{}], opts *PrintOptions) error {
+	return PrintContextIterator(c, iterator.FromIterator(iter), opts)
+}
+
+// PrintContextIterator prints items from an iterator based on user flags or print options.
+// On interrupt (Ctrl-C) it stops fetching items, prints the items received so far and returns without an error.
+// The iterator is closed once printing is done.
+func PrintContextIterator[T any](c *cli.Context, iter iterator.ContextIterator[T], opts *PrintOptions) error {
+	defer iter.Close()
+
+	ctx, stop := notifyInterrupt(c.Context)
+	defer stop()
+
 	limit := c.Int(FlagLimit)
+	offset := c.Int(FlagOffset)
 
 	if opts == nil {
 		opts = &PrintOptions{}
 	}
 
-	itemsPrinted := 0
-	var batch []interface{}
-	for iter.HasNext() {
-		item, err := iter.Next()
-		if err != nil {
-			return err
-		}
+	filter, err := newItemFilter(c)
+	if err != nil {
+		return err
+	}
+
+	sorter, err := newItemSorter(c)
+	if err != nil {
+		return err
+	}
+
+	// json lines and templates are streamed as they are received
+	output := getOutputFormat(c, opts)
+	stream := output == JSONL || output == Template
+	follow := c.Bool(FlagFollow)
 
-		if c.IsSet(FlagLimit) && itemsPrinted >= limit {
-			break
+	// the interactive pager prints a page at a time, asking before fetching the next one
+	interactive := pagerName(c, opts) == string(pager.Interactive) && !follow && !c.Bool(pager.FlagNoPager)
+
+	batchSize := opts.PageSize
+	if batchSize <= 0 {
+		batchSize = pager.DefaultListPageSize
+	}
+	if sorter != nil {
+		if follow {
+			warn(c, "--%v is ignored with --%v", FlagSortBy, FlagFollow)
+			sorter = nil
+		} else {
+			// sorted items are buffered, so they can't be streamed
+			batchSize = opts.SortBufferSize
+			if batchSize <= 0 {
+				batchSize = DefaultSortBufferSize
+			}
+			stream = false
 		}
+	}
+
+	opts.layout = &tableLayout{}
+	opts.template = &parsedTemplate{}
 
-		batch = append(batch, item)
-		itemsPrinted++
+	items := iterator.Map(iter, func(item T) (interface{}, error) {
+		return item, nil
+	})
+	if filter != nil {
+		items = iterator.Filter(items, filter.match)
+	}
+	// sorted items are skipped once sorted, as in PrintItems
+	sortedOffset := 0
+	if sorter != nil {
+		sortedOffset, offset = offset, 0
+	}
+	if offset > 0 {
+		items = iterator.Skip(items, offset)
+	}
+	// --limit 0 prints all items
+	if limit > 0 {
+		items = iterator.Take(items, sortedOffset+limit)
+	}
 
-		follow := c.Bool(FlagFollow)
-		isLastBatch := limit-itemsPrinted < BatchPrintSize
-		isBatchFilled := (len(batch) == BatchPrintSize) || (isLastBatch && len(batch) == limit%BatchPrintSize)
+	// for consistent formatting, print items in batches (ex. in Table output)
+	// else if --follow is on, print items as they are received
+	if (follow || stream) && !interactive {
+		batchSize = 1
+	}
+	batches := iterator.Batch(items, batchSize)
 
-		if follow || isBatchFilled || !iter.HasNext() {
-			// for consistent formatting, print items in batches (ex. in Table output)
-			// else if --follow is on, print items as they are received
-			err = PrintItems(c, batch, opts)
+	// the pager is kept open for all batches
+	return withPager(c, opts, func(w io.Writer) error {
+		// the template is checked before fetching any item
+		if output == Template {
+			tmpl, err := parseTemplate(c, w, c.String(FlagTemplate))
 			if err != nil {
 				return err
 			}
-			batch = batch[:0]
-			opts.NoHeader = true
+			opts.template.tmpl = tmpl
+		}
+
+		return printBatches(ctx, c, w, batches, sorter, sortedOffset, interactive, batchSize, opts)
+	})
+}
+
+func printBatches(ctx context.Context, c *cli.Context, w io.Writer, batches iterator.ContextIterator[[]interface{}],
+	sorter *itemSorter, sortedOffset int, interactive bool, batchSize int, opts *PrintOptions) error {
+	var err error
+	batchesPrinted := 0
+	printBatch := func() (bool, error) {
+		if !batches.Next(ctx) {
+			return false, nil
 		}
+		batch := batches.Value()
+
+		// a partial batch is the last one
+		more := len(batch) == batchSize
+
+		if sorter != nil {
+			if batchesPrinted == 1 {
+				warn(c, "sorting only within batches of %v items. Use --%v to print fewer items", batchSize, FlagLimit)
+			}
+			if batch, err = sorter.sort(batch); err != nil {
+				return false, err
+			}
+
+			skip := sortedOffset
+			if skip > len(batch) {
+				skip = len(batch)
+			}
+			batch, sortedOffset = batch[skip:], sortedOffset-skip
+		}
+		batchesPrinted++
+
+		if len(batch) == 0 {
+			return more, nil
+		}
+		if err := writeItems(c, w, batch, opts); err != nil {
+			return false, err
+		}
+		opts.NoHeader = true
+
+		return more, nil
+	}
+
+	if interactive {
+		err = pager.Paginate(c, printBatch)
+	} else {
+		for more := true; more && err == nil; {
+			more, err = printBatch()
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := batches.Err(); err != nil && !isInterrupted(ctx, c) {
+		return err
 	}
 
 	return nil
 }
 
+// notifyInterrupt returns a context cancelled on the first interrupt signal. The default handling is restored
+// after it, so a second Ctrl-C still kills the app while it's blocked in a fetch that doesn't check the context
+func notifyInterrupt(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// isInterrupted reports whether ctx was cancelled by an interrupt signal rather than by the app
+func isInterrupted(ctx context.Context, c *cli.Context) bool {
+	return ctx.Err() != nil && c.Context.Err() == nil
+}
+
+// warn prints a warning to the error writer of the app
+func warn(c *cli.Context, format string, a ...interface{}) {
+	w := c.App.ErrWriter
+	if w == nil {
+		w = os.Stderr
+	}
+
+	prefix := "warning:"
+	if color.Enabled(c, w) {
+		if theme, err := color.CurrentTheme(c); err == nil {
+			prefix = theme.Sprintf(true, color.RoleWarning, "%s", prefix)
+		}
+	}
+
+	fmt.Fprintf(w, prefix+" "+format+"\n", a...)
+}
+
 func getOutputFormat(c *cli.Context, opts *PrintOptions) OutputOption {
 	outputFlag := c.String(FlagOutput)
 	output := OutputOption(outputFlag)
@@ -145,6 +420,8 @@ func getOutputFormat(c *cli.Context, opts *PrintOptions) OutputOption {
 	if opts != nil {
 		if c.IsSet(FlagOutput) {
 			return output
+		} else if c.IsSet(FlagTemplate) {
+			return Template
 		} else if opts.OutputFormat != "" {
 			return opts.OutputFormat
 		}
@@ -0,0 +1,366 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/iterator"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/temporalio/tctl-kit/pkg/pager"
+	"github.com/urfave/cli/v2"
+)
+
+type dataForPrinter struct {
+	Name   string
+	Value  int
+	Nested struct {
+		NName string
+	}
+}
+
+type sliceIterator struct {
+	items []interface{}
+}
+
+func (s *sliceIterator) HasNext() bool {
+	return len(s.items) > 0
+}
+
+func (s *sliceIterator) Next() (interface{}, error) {
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item, nil
+}
+
+func setupPrinterTest(args ...string) (*cli.Context, func()) {
+	app := cli.NewApp()
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.String(output.FlagOutput, "", "")
+	flagSet.String(output.FlagFields, "", "")
+	flagSet.Int(output.FlagLimit, 0, "")
+	flagSet.Int(output.FlagOffset, 0, "")
+	flagSet.Bool(output.FlagFollow, false, "")
+	flagSet.String(output.FlagTemplate, "", "")
+	flagSet.String(output.FlagFilter, "", "")
+	flagSet.String(output.FlagSortBy, "", "")
+	flagSet.String(color.FlagColor, "", "")
+	flagSet.String(color.FlagTheme, "", "")
+	flagSet.Parse(args)
+	ctx := cli.NewContext(app, flagSet, nil)
+
+	return ctx, func() {}
+}
+
+func newPrinterItems(n int) []interface{} {
+	var items []interface{}
+	for i := 1; i <= n; i++ {
+		item := &dataForPrinter{Name: "foo", Value: i}
+		item.Nested.NName = "bar"
+		items = append(items, item)
+	}
+	return items
+}
+
+func ExamplePrintIterator_jsonLines() {
+	ctx, teardown := setupPrinterTest("--output", "jsonl", "--limit", "3", "--fields", "Value,Nested.NName")
+	defer teardown()
+
+	iter := &sliceIterator{items: newPrinterItems(5)}
+
+	output.PrintIterator(ctx, iter, &output.PrintOptions{})
+
+	// Output:
+	// {"Value":1,"Nested.NName":"bar"}
+	// {"Value":2,"Nested.NName":"bar"}
+	// {"Value":3,"Nested.NName":"bar"}
+}
+
+func ExamplePrintContextIterator_pages() {
+	ctx, teardown := setupPrinterTest("--output", "jsonl", "--limit", "3", "--fields", "Value")
+	defer teardown()
+
+	fetch := func(ctx context.Context, token []byte) ([]*dataForPrinter, []byte, error) {
+		if len(token) == 0 {
+			return []*dataForPrinter{{Value: 1}, {Value: 2}}, []byte("next"), nil
+		}
+		return []*dataForPrinter{{Value: 3}, {Value: 4}}, nil, nil
+	}
+
+	output.PrintContextIterator(ctx, iterator.NewPageIterator(fetch, nil), &output.PrintOptions{})
+
+	// Output:
+	// {"Value":1}
+	// {"Value":2}
+	// {"Value":3}
+}
+
+func ExamplePrintItems_jsonLines() {
+	ctx, teardown := setupPrinterTest("--output", "jsonl")
+	defer teardown()
+
+	output.PrintItems(ctx, newPrinterItems(2), &output.PrintOptions{})
+
+	// Output:
+	// {"Name":"foo","Value":1,"Nested":{"NName":"bar"}}
+	// {"Name":"foo","Value":2,"Nested":{"NName":"bar"}}
+}
+
+func ExamplePrintItems_offset() {
+	ctx, teardown := setupPrinterTest("--output", "jsonl", "--fields", "Value", "--offset", "3")
+	defer teardown()
+
+	output.PrintItems(ctx, newPrinterItems(5), &output.PrintOptions{})
+
+	// Output:
+	// {"Value":4}
+	// {"Value":5}
+}
+
+type failingIterator struct {
+	items []interface{}
+	err   error
+}
+
+func (f *failingIterator) Next(ctx context.Context) bool {
+	if len(f.items) == 0 {
+		return false
+	}
+	f.items = f.items[1:]
+	return true
+}
+
+func (f *failingIterator) Value() interface{} {
+	return &dataForPrinter{Name: "foo"}
+}
+
+func (f *failingIterator) Err() error {
+	if len(f.items) == 0 {
+		return f.err
+	}
+	return nil
+}
+
+func (f *failingIterator) Close() error {
+	return nil
+}
+
+func TestPrintContextIterator_Error(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--template", "{{.Name}}")
+	defer teardown()
+
+	var buf bytes.Buffer
+	ctx.App.Writer = &buf
+
+	errFetch := errors.New("fetch failed")
+	iter := &failingIterator{items: newPrinterItems(2), err: errFetch}
+
+	err := output.PrintContextIterator[interface{}](ctx, iter, &output.PrintOptions{})
+	assert.ErrorIs(t, err, errFetch)
+	assert.Equal(t, "foo\nfoo\n", buf.String())
+}
+
+// fetchCounter counts the items fetched from the iterator
+type fetchCounter struct {
+	iterator.ContextIterator[interface{}]
+	fetched int
+}
+
+func (f *fetchCounter) Next(ctx context.Context) bool {
+	ok := f.ContextIterator.Next(ctx)
+	if ok {
+		f.fetched++
+	}
+	return ok
+}
+
+// batchWriter keeps each write separately, csv output writes each printed batch at once
+type batchWriter struct {
+	batches []string
+}
+
+func (b *batchWriter) Write(p []byte) (int, error) {
+	b.batches = append(b.batches, string(p))
+	return len(p), nil
+}
+
+func TestPrintContextIterator_Batches(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		pageSize    int
+		items       int
+		wantBatches []string
+		wantFetched int
+	}{
+		"no limit": {
+			pageSize: 2, items: 5,
+			wantBatches: []string{"1\n2\n", "3\n4\n", "5\n"},
+			wantFetched: 5,
+		},
+		"zero limit": {
+			args: []string{"--limit", "0"}, pageSize: 2, items: 3,
+			wantBatches: []string{"1\n2\n", "3\n"},
+			wantFetched: 3,
+		},
+		"limit within batch": {
+			args: []string{"--limit", "3"}, pageSize: 2, items: 5,
+			wantBatches: []string{"1\n2\n", "3\n"},
+			wantFetched: 3,
+		},
+		"limit at batch boundary": {
+			args: []string{"--limit", "4"}, pageSize: 2, items: 5,
+			wantBatches: []string{"1\n2\n", "3\n4\n"},
+			wantFetched: 4,
+		},
+		"limit over items": {
+			args: []string{"--limit", "10"}, pageSize: 2, items: 3,
+			wantBatches: []string{"1\n2\n", "3\n"},
+			wantFetched: 3,
+		},
+		"limit under page size": {
+			args: []string{"--limit", "2"}, pageSize: 0, items: 5,
+			wantBatches: []string{"1\n2\n"},
+			wantFetched: 2,
+		},
+		"default page size": {
+			pageSize: 0, items: pager.DefaultListPageSize + 1,
+			wantBatches: []string{
+				"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n17\n18\n19\n20\n",
+				"21\n",
+			},
+			wantFetched: pager.DefaultListPageSize + 1,
+		},
+		"offset": {
+			args: []string{"--offset", "2"}, pageSize: 2, items: 5,
+			wantBatches: []string{"3\n4\n", "5\n"},
+			wantFetched: 5,
+		},
+		"offset and limit": {
+			args: []string{"--offset", "2", "--limit", "2"}, pageSize: 2, items: 5,
+			wantBatches: []string{"3\n4\n"},
+			wantFetched: 4,
+		},
+		"offset past items": {
+			args: []string{"--offset", "5"}, pageSize: 2, items: 3,
+			wantBatches: nil,
+			wantFetched: 3,
+		},
+		"offset after filter": {
+			args: []string{"--offset", "1", "--filter", "Value > 2"}, pageSize: 2, items: 5,
+			wantBatches: []string{"4\n5\n"},
+			wantFetched: 5,
+		},
+		"offset after sort": {
+			args: []string{"--sort-by", "Value,desc", "--offset", "1"}, pageSize: 2, items: 5,
+			wantBatches: []string{"4\n3\n2\n1\n"},
+			wantFetched: 5,
+		},
+		"offset and limit after sort": {
+			args: []string{"--sort-by", "Value,desc", "--offset", "1", "--limit", "2"}, pageSize: 2, items: 5,
+			wantBatches: []string{"2\n1\n"},
+			wantFetched: 3,
+		},
+		"follow": {
+			args: []string{"--follow", "--limit", "3"}, pageSize: 2, items: 5,
+			wantBatches: []string{"1\n", "2\n", "3\n"},
+			wantFetched: 3,
+		},
+		"follow and offset": {
+			args: []string{"--follow", "--offset", "3"}, pageSize: 2, items: 5,
+			wantBatches: []string{"4\n", "5\n"},
+			wantFetched: 5,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			args := append([]string{"--output", "csv", "--fields", "Value"}, tt.args...)
+			ctx, teardown := setupPrinterTest(args...)
+			defer teardown()
+
+			var w batchWriter
+			ctx.App.Writer = &w
+
+			iter := &fetchCounter{ContextIterator: iterator.FromSlice(newPrinterItems(tt.items))}
+			err := output.PrintContextIterator[interface{}](ctx, iter, &output.PrintOptions{NoHeader: true, PageSize: tt.pageSize})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantBatches, w.batches)
+			assert.Equal(t, tt.wantFetched, iter.fetched)
+		})
+	}
+}
+
+func TestOffsetAfterSort(t *testing.T) {
+	newItems := func() []interface{} {
+		return []interface{}{&dataForPrinter{Name: "c"}, &dataForPrinter{Name: "a"}, &dataForPrinter{Name: "b"}}
+	}
+
+	tests := map[string]func(c *cli.Context) error{
+		"PrintItems": func(c *cli.Context) error {
+			return output.PrintItems(c, newItems(), &output.PrintOptions{})
+		},
+		"PrintContextIterator": func(c *cli.Context) error {
+			return output.PrintContextIterator(c, iterator.FromSlice(newItems()), &output.PrintOptions{})
+		},
+	}
+
+	for name, print := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, teardown := setupPrinterTest("--output", "jsonl", "--fields", "Name", "--sort-by", "Name", "--offset", "1")
+			defer teardown()
+
+			var buf bytes.Buffer
+			ctx.App.Writer = &buf
+
+			assert.NoError(t, print(ctx))
+			assert.Equal(t, "{\"Name\":\"b\"}\n{\"Name\":\"c\"}\n", buf.String())
+		})
+	}
+}
+
+func TestPrintContextIterator_InteractiveNonTTY(t *testing.T) {
+	t.Setenv("PAGER", "")
+
+	ctx, teardown := setupPrinterTest("--output", "csv", "--fields", "Value", "--limit", "25")
+	defer teardown()
+
+	var w batchWriter
+	ctx.App.Writer = &w
+
+	iter := iterator.FromSlice(newPrinterItems(30))
+	err := output.PrintContextIterator(ctx, iter, &output.PrintOptions{NoHeader: true, Pager: pager.Interactive})
+	assert.NoError(t, err)
+
+	// pages of pager.DefaultListPageSize are printed without prompting
+	if assert.Len(t, w.batches, 2) {
+		assert.Equal(t, pager.DefaultListPageSize, strings.Count(w.batches[0], "\n"))
+		assert.Equal(t, 5, strings.Count(w.batches[1], "\n"))
+	}
+}
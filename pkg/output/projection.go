@@ -0,0 +1,85 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// projectedItem is a subset of item fields in the order they were selected.
+// It is used to print structured output (JSON, YAML..) for the selected --fields.
+type projectedItem []projectedField
+
+type projectedField struct {
+	Name  string
+	Value interface{}
+}
+
+func projectItems(items []interface{}, fields []string) ([]interface{}, error) {
+	valuesList, err := extractFieldValues(items, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	projected := make([]interface{}, len(valuesList))
+	for i, values := range valuesList {
+		item := make(projectedItem, len(values))
+		for j, value := range values {
+			item[j] = projectedField{Name: fieldLabel(fields[j]), Value: value}
+		}
+		projected[i] = item
+	}
+
+	return projected, nil
+}
+
+// MarshalJSON encodes the fields as a JSON object, keeping the selected order.
+// Proto messages are encoded with their JSON field names.
+func (p projectedItem) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := ParseToJSON(f.Value, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode field %v: %w", f.Name, err)
+		}
+
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.WriteString(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -39,32 +39,24 @@ func extractFieldValues(objs []interface{}, fields []string) ([][]interface{}, e
 		return [][]interface{}{}, nil
 	}
 
-	knownFields := extractFieldNames(objs[0], []string{}, "", fieldsDepth)
-
 	if len(fields) == 0 {
-		fields = knownFields
+		fields = extractFieldNames(objs[0], []string{}, "", fieldsDepth)
+	}
+
+	paths, err := parseFieldPaths(fields)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := validateFields(knownFields, fields); err != nil {
+	if err := validateFields(objs[0], paths); err != nil {
 		return nil, err
 	}
 
 	var result = make([][]interface{}, len(objs))
 	for i, item := range objs {
-		result[i] = make([]interface{}, len(fields))
-		val := reflect.ValueOf(item)
-		for j, field := range fields {
-			nestedFields := splitFieldPath(field)
-			var col interface{}
-			for _, nField := range nestedFields {
-				val = reflect.Indirect(val)
-				val = val.FieldByName(nField)
-				col = val.Interface()
-				val = reflect.ValueOf(col)
-			}
-			result[i][j] = col
-
-			val = reflect.ValueOf(item)
+		result[i] = make([]interface{}, len(paths))
+		for j, path := range paths {
+			result[i][j] = path.eval(item)
 		}
 	}
 
@@ -92,7 +84,7 @@ func extractFieldNames(obj interface{}, fieldNames []string, parentField string,
 		fieldNames = append(fieldNames, fieldName)
 
 		// recursively examine nested fields
-		subval := val.FieldByName(fieldName)
+		subval := val.Field(i)
 		subval = reflect.Indirect(subval)
 		isFieldValid := subval.Kind() == reflect.Struct && subval.CanInterface()
 
@@ -104,18 +96,13 @@ func extractFieldNames(obj interface{}, fieldNames []string, parentField string,
 	return fieldNames
 }
 
-func validateFields(allowedFields []string, fields []string) error {
-	for _, f := range fields {
-		contains := false
-		for _, a := range allowedFields {
-			if strings.Compare(f, a) == 0 {
-				contains = true
-				break
-			}
-		}
-		if !contains {
+// validateFields checks that the paths can be resolved on obj. The error lists the available fields
+func validateFields(obj interface{}, paths []*fieldPath) error {
+	for _, path := range paths {
+		if err := path.validate(reflect.TypeOf(obj)); err != nil {
+			allowedFields := extractFieldNames(obj, []string{}, "", fieldsDepth)
 			fieldsStr := `"` + strings.Join(allowedFields, `","`) + `"`
-			return fmt.Errorf("unknown field %v.\nAvailable fields: %v", f, fieldsStr)
+			return fmt.Errorf("%w.\nAvailable fields: %v", err, fieldsStr)
 		}
 	}
 	return nil
@@ -124,7 +111,3 @@ func validateFields(allowedFields []string, fields []string) error {
 func isFieldExported(field reflect.StructField) bool {
 	return field.PkgPath == ""
 }
-
-func splitFieldPath(field string) []string {
-	return strings.Split(field, ".") // results in ex. "Execution", "RunId"
-}
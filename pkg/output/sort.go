@@ -0,0 +1,149 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// DefaultSortBufferSize is the default number of items PrintIterator buffers to sort with --sort-by
+	DefaultSortBufferSize = 1000
+
+	sortDesc = "desc"
+	sortAsc  = "asc"
+)
+
+// itemSorter sorts items by the --sort-by flag, ex. "StartTime,desc,Name"
+// sorts by StartTime in descending order, then by Name in ascending order.
+type itemSorter struct {
+	keys      []sortKey
+	validated bool
+}
+
+type sortKey struct {
+	path *fieldPath
+	desc bool
+}
+
+// newItemSorter returns the sorter provided with the --sort-by flag, or nil if there is none.
+func newItemSorter(c *cli.Context) (*itemSorter, error) {
+	sortBy := strings.TrimSpace(c.String(FlagSortBy))
+	if sortBy == "" {
+		return nil, nil
+	}
+
+	sorter := &itemSorter{}
+	for _, f := range splitFields(sortBy) {
+		switch strings.ToLower(f) {
+		case sortDesc, sortAsc:
+			if len(sorter.keys) == 0 {
+				return nil, fmt.Errorf("invalid sort-by %v: %v must follow a field", sortBy, f)
+			}
+			sorter.keys[len(sorter.keys)-1].desc = strings.ToLower(f) == sortDesc
+		default:
+			path, err := parseFieldPath(f)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sort-by %v: %w", sortBy, err)
+			}
+			sorter.keys = append(sorter.keys, sortKey{path: path})
+		}
+	}
+
+	return sorter, nil
+}
+
+// sort returns the sorted items, keeping the order of equal items
+func (s *itemSorter) sort(items []interface{}) ([]interface{}, error) {
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	if !s.validated {
+		paths := make([]*fieldPath, len(s.keys))
+		for i, key := range s.keys {
+			paths[i] = key.path
+		}
+		if err := validateFields(items[0], paths); err != nil {
+			return nil, fmt.Errorf("invalid sort-by: %w", err)
+		}
+		s.validated = true
+	}
+
+	values := make([][]interface{}, len(items))
+	for i, item := range items {
+		values[i] = make([]interface{}, len(s.keys))
+		for j, key := range s.keys {
+			values[i][j] = key.path.eval(item)
+		}
+	}
+
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		for j, key := range s.keys {
+			cmp := compareSortValues(values[indexes[a]][j], values[indexes[b]][j])
+			if cmp == 0 {
+				continue
+			}
+			if key.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+
+	sorted := make([]interface{}, len(items))
+	for i, index := range indexes {
+		sorted[i] = items[index]
+	}
+
+	return sorted, nil
+}
+
+// compareSortValues compares values by type, falling back to their string representation
+func compareSortValues(a, b interface{}) int {
+	cmp, err := compareValues(a, b)
+	if err != nil {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+	return cmp
+}
+
+// sortItems returns items sorted by the --sort-by flag
+func sortItems(c *cli.Context, items []interface{}) ([]interface{}, error) {
+	sorter, err := newItemSorter(c)
+	if err != nil || sorter == nil {
+		return items, err
+	}
+
+	return sorter.sort(items)
+}
@@ -0,0 +1,103 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/output"
+)
+
+func TestSortBy(t *testing.T) {
+	testcases := map[string]struct {
+		sortBy string
+		expect string
+		err    string
+	}{
+		"time ascending": {
+			sortBy: "StartTime",
+			expect: "d,c,b,a",
+		},
+		"time descending": {
+			sortBy: "StartTime,desc",
+			expect: "a,b,c,d",
+		},
+		"numbers": {
+			sortBy: "Attempt,desc",
+			expect: "c,b,a,d",
+		},
+		"durations": {
+			sortBy: "Timeout",
+			expect: "c,d,a,b",
+		},
+		"multiple fields": {
+			sortBy: "Status,desc,Name,desc",
+			expect: "d,c,a,b",
+		},
+		"unknown field": {
+			sortBy: "State",
+			err:    "invalid sort-by: unknown field State",
+		},
+		"misplaced order": {
+			sortBy: "desc,Name",
+			err:    "invalid sort-by desc,Name: desc must follow a field",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, teardown := setupPrinterTest("--template", "{{.Name}}", "--sort-by", tc.sortBy)
+			defer teardown()
+
+			var buf bytes.Buffer
+			ctx.App.Writer = &buf
+
+			err := output.PrintItems(ctx, newFilterItems(), &output.PrintOptions{})
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expect, strings.Join(strings.Fields(buf.String()), ","))
+			}
+		})
+	}
+}
+
+func TestSortByIterator(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--template", "{{.Value}}", "--sort-by", "Value,desc")
+	defer teardown()
+
+	var out, errOut bytes.Buffer
+	ctx.App.Writer = &out
+	ctx.App.ErrWriter = &errOut
+
+	iter := &sliceIterator{items: newPrinterItems(5)}
+
+	err := output.PrintIterator(ctx, iter, &output.PrintOptions{SortBufferSize: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "3,2,1,5,4", strings.Join(strings.Fields(out.String()), ","))
+	assert.Contains(t, errOut.String(), "sorting only within batches of 3 items")
+}
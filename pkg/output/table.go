@@ -27,57 +27,138 @@ package output
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/urfave/cli/v2"
 )
 
-var (
-	headerColor = tablewriter.Colors{tablewriter.FgHiMagentaColor}
-)
-
 func PrintTable(c *cli.Context, w io.Writer, items []interface{}, opts *PrintOptions) error {
-	colorFlag := c.String(color.FlagColor)
-	enableColor := colorFlag == string(color.Auto) || colorFlag == string(color.Always)
+	enableColor := color.Enabled(c, w)
 	fields := opts.Fields
 	table := tablewriter.NewWriter(w)
 	table.SetBorder(false)
 	table.SetColumnSeparator(opts.Separator)
+	// long values are truncated or wrapped to the column widths below
+	table.SetAutoWrapText(false)
 
-	if !opts.NoHeader {
-		headerNames := make([]string, len(fields))
-		for i, f := range fields {
-			nestedFields := splitFieldPath(f)
-			headerNames[i] = nestedFields[len(nestedFields)-1]
+	rows, err := extractFieldValues(items, fields)
+	if err != nil {
+		return fmt.Errorf("unable to print table: %w", err)
+	}
+
+	headerNames := make([]string, len(fields))
+	for i, f := range fields {
+		headerNames[i] = fieldHeader(f)
+	}
+
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for j, column := range row {
+			cells[i][j] = formatField(c, column)
+		}
+	}
+
+	var roles [][]color.Role
+	if enableColor {
+		roles = opts.cellRoles
+		if roles == nil {
+			roles = valueRoles(opts, fields, rows, cells)
+		}
+	}
+
+	if opts.changes != nil {
+		fields = append([]string{changeField}, fields...)
+		headerNames = append([]string{changeField}, headerNames...)
+		for i := range cells {
+			cells[i] = append([]string{string(opts.changes[i])}, cells[i]...)
+		}
+
+		if enableColor {
+			// the change column is colored even without value roles
+			if roles == nil {
+				roles = make([][]color.Role, len(cells))
+				for i := range roles {
+					roles[i] = make([]color.Role, len(fields)-1)
+				}
+			}
+			for i := range roles {
+				roles[i] = append([]color.Role{changeRoles[opts.changes[i]]}, roles[i]...)
+			}
+		}
+	}
+
+	wide := c.Bool(FlagWide)
+
+	// when printing in batches, the widths of the first batch are kept for the rest
+	var widths []int
+	if opts.layout != nil && opts.layout.widths != nil {
+		widths = opts.layout.widths
+	} else {
+		widths = columnWidths(opts, fields, headerNames, cells, terminalWidth(), wide)
+		if opts.layout != nil {
+			opts.layout.widths = widths
 		}
+	}
+
+	for j, width := range widths {
+		if !wide {
+			headerNames[j] = fitCell(headerNames[j], width, opts.Wrap)
+			for i := range cells {
+				cells[i][j] = fitCell(cells[i][j], width, opts.Wrap)
+			}
+		}
+		table.SetColMinWidth(j, width)
+	}
+
+	var theme color.Theme
+	if enableColor {
+		if theme, err = color.CurrentTheme(c); err != nil {
+			return fmt.Errorf("unable to print table: %w", err)
+		}
+
+		// colors are added once the cells are fitted, so they don't count in the widths
+		for j, field := range fields {
+			for i := range cells {
+				role := opts.columnRoles[field]
+				if roles != nil && roles[i][j] != "" {
+					role = roles[i][j]
+				}
+				if role != "" {
+					cells[i][j] = colorLines(theme[role], cells[i][j])
+				}
+			}
+		}
+	}
+
+	if !opts.NoHeader {
 		table.SetHeader(headerNames)
 		table.SetAutoFormatHeaders(false)
 
 		if enableColor {
 			headerColors := make([]tablewriter.Colors, len(fields))
 			for i := range headerColors {
-				headerColors[i] = headerColor
+				headerColors[i] = theme[color.RoleHeader].Ints()
 			}
 			table.SetHeaderColor(headerColors...)
 		}
 		table.SetHeaderLine(false)
 	}
 
-	rows, err := extractFieldValues(items, fields)
-	if err != nil {
-		return fmt.Errorf("unable to print table: %w", err)
-	}
-
-	for _, row := range rows {
-		columns := make([]string, len(row))
-		for j, column := range row {
-			columns[j] = formatField(c, column)
-		}
-		table.Append(columns)
-	}
+	table.AppendBulk(cells)
 	table.Render()
 	table.ClearRows()
 
 	return nil
 }
+
+// colorLines colors each line of a wrapped cell separately, as the table prints them on separate rows
+func colorLines(style color.Style, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = style.Sprintf(true, "%s", line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -25,9 +25,15 @@
 package output_test
 
 import (
+	"bytes"
 	"flag"
 	"os"
+	"strings"
+	"testing"
 
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/temporalio/tctl-kit/pkg/output"
 	"github.com/urfave/cli/v2"
 )
@@ -82,3 +88,286 @@ func ExamplePrintTable() {
 	// Output:
 	// foo1  bar1  baz1  qux1
 }
+
+func ExamplePrintTable_maxColumnWidths() {
+	ctx, teardown := setupTableTest()
+	defer teardown()
+
+	items := []interface{}{
+		&dataForTable{Name: "foo1", Value: "a long value that doesn't fit"},
+	}
+
+	po := output.PrintOptions{
+		Fields:          []string{"Name", "Value"},
+		NoHeader:        true,
+		MaxColumnWidths: map[string]int{"Value": 10},
+	}
+
+	output.PrintTable(ctx, os.Stdout, items, &po)
+
+	// Output:
+	// foo1  a long va…
+}
+
+func TestPrintTable_Wrap(t *testing.T) {
+	ctx, teardown := setupTableTest()
+	defer teardown()
+
+	items := []interface{}{
+		&dataForTable{Name: "foo1", Value: "a long value that doesn't fit"},
+	}
+
+	po := output.PrintOptions{
+		Fields:          []string{"Name", "Value"},
+		NoHeader:        true,
+		MaxColumnWidths: map[string]int{"Value": 10},
+		Wrap:            true,
+	}
+
+	var buf bytes.Buffer
+	err := output.PrintTable(ctx, &buf, items, &po)
+	assert.NoError(t, err)
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	assert.Equal(t, []string{"foo1  a long val", "ue that do", "esn't fit"}, lines)
+}
+
+func TestPrintTable_Theme(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--color", "always", "--theme", "high-contrast")
+	defer teardown()
+
+	items := []interface{}{&dataForTable{Name: "foo1"}}
+
+	var buf bytes.Buffer
+	err := output.PrintTable(ctx, &buf, items, &output.PrintOptions{Fields: []string{"Name"}})
+	assert.NoError(t, err)
+	// the header role of the theme: bold, underline, hi-white
+	assert.Contains(t, buf.String(), "\x1b[1;4;97mName")
+
+	ctx, teardown = setupPrinterTest("--color", "always", "--theme", "unknown")
+	defer teardown()
+
+	err = output.PrintTable(ctx, &buf, items, &output.PrintOptions{Fields: []string{"Name"}})
+	assert.ErrorContains(t, err, "unknown theme unknown")
+}
+
+func TestPrintCards_Theme(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--color", "always")
+	defer teardown()
+
+	items := []interface{}{&dataForTable{Name: "foo1"}}
+
+	var buf bytes.Buffer
+	err := output.PrintCards(ctx, &buf, items, &output.PrintOptions{Fields: []string{"Name"}})
+	assert.NoError(t, err)
+	// the key role of the default theme: cyan
+	assert.Equal(t, "  \x1b[36mName\x1b[0m  foo1  \n\n", buf.String())
+}
+
+func TestPrintTable_ColorRules(t *testing.T) {
+	items := []interface{}{
+		&dataForTable{Name: "foo1", Value: "Running"},
+		&dataForTable{Name: "foo2", Value: "Failed"},
+		&dataForTable{Name: "foo3", Value: "Canceled"},
+	}
+
+	tests := map[string]struct {
+		args []string
+		opts *output.PrintOptions
+		want []string
+	}{
+		"rules": {
+			args: []string{"--color", "always"},
+			opts: &output.PrintOptions{
+				ColorRules: map[string]map[string]color.Role{"Value": {"Running": color.RoleSuccess, "Failed": color.RoleError}},
+			},
+			want: []string{"foo1  \x1b[32mRunning\x1b[0m", "foo2  \x1b[31mFailed\x1b[0m", "foo3  Canceled"},
+		},
+		"colorizer over rules": {
+			args: []string{"--color", "always"},
+			opts: &output.PrintOptions{
+				ColorRules: map[string]map[string]color.Role{"Value": {"Running": color.RoleSuccess}},
+				Colorizer: func(field string, value interface{}) color.Role {
+					if field == "Name" && value == "foo1" {
+						return color.RoleMuted
+					}
+					if value == "Running" {
+						return color.RoleStatus
+					}
+					return ""
+				},
+			},
+			want: []string{"\x1b[90mfoo1\x1b[0m  \x1b[34mRunning\x1b[0m", "foo2  Failed", "foo3  Canceled"},
+		},
+		"color disabled": {
+			args: []string{"--color", "never"},
+			opts: &output.PrintOptions{
+				ColorRules: map[string]map[string]color.Role{"Value": {"Running": color.RoleSuccess}},
+			},
+			want: []string{"foo1  Running", "foo2  Failed", "foo3  Canceled"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, teardown := setupPrinterTest(tt.args...)
+			defer teardown()
+
+			tt.opts.Fields = []string{"Name", "Value"}
+			tt.opts.NoHeader = true
+
+			var buf bytes.Buffer
+			err := output.PrintTable(ctx, &buf, items, tt.opts)
+			assert.NoError(t, err)
+
+			var lines []string
+			for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+				lines = append(lines, strings.TrimSpace(line))
+			}
+			assert.Equal(t, tt.want, lines)
+		})
+	}
+}
+
+func TestPrintCards_ColorRules(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--color", "always")
+	defer teardown()
+
+	items := []interface{}{&dataForTable{Name: "foo1", Value: "Failed"}}
+	opts := &output.PrintOptions{
+		Fields:     []string{"Value"},
+		ColorRules: map[string]map[string]color.Role{"Value": {"Failed": color.RoleError}},
+	}
+
+	var buf bytes.Buffer
+	err := output.PrintCards(ctx, &buf, items, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "  \x1b[36mValue\x1b[0m  \x1b[31mFailed\x1b[0m  \n\n", buf.String())
+}
+
+func TestPrintCSV_NotColored(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--color", "always")
+	defer teardown()
+
+	items := []interface{}{&dataForTable{Name: "foo1", Value: "Failed"}}
+	opts := &output.PrintOptions{
+		Fields:     []string{"Value"},
+		ColorRules: map[string]map[string]color.Role{"Value": {"Failed": color.RoleError}},
+	}
+
+	var buf bytes.Buffer
+	err := output.PrintCSV(ctx, &buf, items, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "Value\nFailed\n", buf.String())
+}
+
+func TestPrintTable_TerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "30")
+
+	items := []interface{}{
+		&dataForTable{Name: "foo1", Value: strings.Repeat("x", 50)},
+	}
+
+	testcases := map[string]struct {
+		args   []string
+		expect string
+	}{
+		"fits into terminal": {
+			expect: "  foo1  " + strings.Repeat("x", 19) + "…  \n",
+		},
+		"wide": {
+			args:   []string{"--wide"},
+			expect: "  foo1  " + strings.Repeat("x", 50) + "  \n",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			app := cli.NewApp()
+			flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+			flagSet.Bool(output.FlagWide, false, "")
+			flagSet.Parse(tc.args)
+			ctx := cli.NewContext(app, flagSet, nil)
+
+			po := output.PrintOptions{
+				Fields:   []string{"Name", "Value"},
+				NoHeader: true,
+			}
+
+			var buf bytes.Buffer
+			err := output.PrintTable(ctx, &buf, items, &po)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, buf.String())
+		})
+	}
+}
+
+func TestPrintIterator_TableAlignedAcrossBatches(t *testing.T) {
+	app := cli.NewApp()
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Bool(output.FlagFollow, false, "")
+	flagSet.Parse([]string{"--follow"})
+	ctx := cli.NewContext(app, flagSet, nil)
+
+	var buf bytes.Buffer
+	app.Writer = &buf
+
+	iter := &sliceIterator{items: []interface{}{
+		&dataForTable{Name: "foo1", Value: "bar1"},
+		&dataForTable{Name: "f", Value: "a longer value"},
+		&dataForTable{Name: "foo3", Value: "b"},
+	}}
+
+	po := output.PrintOptions{
+		Fields:       []string{"Name", "Value"},
+		ColumnWidths: map[string]int{"Value": 6},
+	}
+
+	err := output.PrintIterator(ctx, iter, &po)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"  Name  Value   ",
+		"  foo1  bar1    ",
+		"  f     a lon…  ",
+		"  foo3  b       ",
+	}, lines)
+}
+
+func TestPrintIterator_TableFollowKeepsHeaderWidths(t *testing.T) {
+	app := cli.NewApp()
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Bool(output.FlagFollow, false, "")
+	flagSet.Parse([]string{"--follow"})
+	ctx := cli.NewContext(app, flagSet, nil)
+
+	var buf bytes.Buffer
+	app.Writer = &buf
+
+	iter := &sliceIterator{items: []interface{}{
+		&dataForTable{Name: "foo1", Value: "bar1"},
+		&dataForTable{Name: "a longer name", Value: "b"},
+		&dataForTable{Name: "foo3", Value: "a longer value"},
+	}}
+
+	po := output.PrintOptions{Fields: []string{"Name", "Value"}}
+
+	err := output.PrintIterator(ctx, iter, &po)
+	assert.NoError(t, err)
+
+	// the widths of the first item are kept, the longer values of later items are truncated under the header
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"  Name  Value  ",
+		"  foo1  bar1   ",
+		"  a l…  b      ",
+		"  foo3  a lo…  ",
+	}, lines)
+	for _, line := range lines[1:] {
+		assert.Equal(t, runewidth.StringWidth(lines[0]), runewidth.StringWidth(line))
+	}
+}
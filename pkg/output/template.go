@@ -0,0 +1,141 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/temporalio/tctl-kit/pkg/color"
+	"github.com/temporalio/tctl-kit/pkg/format"
+	"github.com/urfave/cli/v2"
+)
+
+// PrintTemplate applies a Go text/template to each item and prints the results on separate lines.
+// Besides the builtin functions, the template can use:
+//   - time: formats a time.Time according to the --time-format flag
+//   - json: encodes a value as compact JSON
+//   - color: colors a value by color or theme role, ex. {{color "green" .Status}} or {{color "error" .Failure}}
+func PrintTemplate(c *cli.Context, w io.Writer, items []interface{}, text string) error {
+	tmpl, err := parseTemplate(c, w, text)
+	if err != nil {
+		return err
+	}
+
+	return executeTemplate(w, items, tmpl)
+}
+
+// printTemplate prints items with the --template flag. The template is parsed once per PrintIterator run
+func printTemplate(c *cli.Context, w io.Writer, items []interface{}, opts *PrintOptions) error {
+	if opts.template != nil && opts.template.tmpl != nil {
+		return executeTemplate(w, items, opts.template.tmpl)
+	}
+
+	tmpl, err := parseTemplate(c, w, c.String(FlagTemplate))
+	if err != nil {
+		return err
+	}
+	if opts.template != nil {
+		opts.template.tmpl = tmpl
+	}
+
+	return executeTemplate(w, items, tmpl)
+}
+
+// parsedTemplate keeps the template parsed for the items of all batches
+type parsedTemplate struct {
+	tmpl *template.Template
+}
+
+func parseTemplate(c *cli.Context, w io.Writer, text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("unable to parse template: template is empty, set it with --%v", FlagTemplate)
+	}
+
+	enableColor := color.Enabled(c, w)
+
+	// the theme is resolved even without colors, so role names are valid in the template
+	theme, err := color.CurrentTheme(c)
+	if err != nil {
+		return nil, fmt.Errorf("unable to print template: %w", err)
+	}
+
+	tmpl, err := template.New("output").Funcs(templateFuncs(c, enableColor, theme)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+func executeTemplate(w io.Writer, items []interface{}, tmpl *template.Template) error {
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("unable to print template: %w", err)
+		}
+
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func templateFuncs(c *cli.Context, enableColor bool, theme color.Theme) template.FuncMap {
+	return template.FuncMap{
+		"time": func(t interface{}) (string, error) {
+			switch t := t.(type) {
+			case time.Time:
+				return format.FormatTime(c, t), nil
+			case *time.Time:
+				return format.FormatTime(c, format.TimeValue(t)), nil
+			default:
+				return "", fmt.Errorf("time: unsupported type %T", t)
+			}
+		},
+		"json": func(o interface{}) (string, error) {
+			return ParseToJSON(o, false)
+		},
+		"color": func(name string, o interface{}) (string, error) {
+			if style, ok := theme[color.Role(name)]; ok {
+				return style.Sprintf(enableColor, "%v", o), nil
+			}
+
+			text, err := color.Sprintf(enableColor, name, "%v", o)
+			if err != nil {
+				return "", fmt.Errorf("color: %w", err)
+			}
+			return text, nil
+		},
+	}
+}
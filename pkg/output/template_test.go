@@ -0,0 +1,121 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/iterator"
+	"github.com/temporalio/tctl-kit/pkg/output"
+)
+
+func ExamplePrintTemplate() {
+	ctx, teardown := setupPrinterTest()
+	defer teardown()
+
+	output.PrintTemplate(ctx, os.Stdout, newPrinterItems(2), `{{.Value}} {{.Name}} {{json .Nested}}`)
+
+	// Output:
+	// 1 foo {"NName":"bar"}
+	// 2 foo {"NName":"bar"}
+}
+
+func ExamplePrintIterator_template() {
+	ctx, teardown := setupPrinterTest("--template", "{{.Name}}-{{.Value}}", "--limit", "2")
+	defer teardown()
+
+	iter := &sliceIterator{items: newPrinterItems(5)}
+
+	output.PrintIterator(ctx, iter, &output.PrintOptions{})
+
+	// Output:
+	// foo-1
+	// foo-2
+}
+
+func TestPrintTemplate_Color(t *testing.T) {
+	tests := map[string]struct {
+		colorOpt string
+		text     string
+		want     string
+		wantErr  string
+	}{
+		"role": {
+			colorOpt: "always", text: `{{color "error" .Name}}`,
+			want: "\x1b[31mfoo\x1b[0m\n",
+		},
+		"role without color": {
+			colorOpt: "never", text: `{{color "error" .Name}}`,
+			want: "foo\n",
+		},
+		"style": {
+			colorOpt: "always", text: `{{color "bold green" .Name}}`,
+			want: "\x1b[1;32mfoo\x1b[0m\n",
+		},
+		"style without color": {
+			colorOpt: "never", text: `{{color "bold green" .Name}}`,
+			want: "foo\n",
+		},
+		"unknown color": {
+			colorOpt: "never", text: `{{color "blinking" .Name}}`,
+			wantErr: "unknown color blinking",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, teardown := setupPrinterTest("--color", tt.colorOpt)
+			defer teardown()
+
+			var buf bytes.Buffer
+			err := output.PrintTemplate(ctx, &buf, newPrinterItems(1), tt.text)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestPrintTemplate_Empty(t *testing.T) {
+	ctx, teardown := setupPrinterTest("--output", "template")
+	defer teardown()
+
+	var buf bytes.Buffer
+	ctx.App.Writer = &buf
+
+	err := output.PrintItems(ctx, newPrinterItems(2), &output.PrintOptions{})
+	assert.ErrorContains(t, err, "template is empty")
+
+	// the template is checked before fetching the items
+	iter := &fetchCounter{ContextIterator: iterator.FromSlice(newPrinterItems(2))}
+	err = output.PrintContextIterator[interface{}](ctx, iter, &output.PrintOptions{})
+	assert.ErrorContains(t, err, "template is empty")
+	assert.Equal(t, 0, iter.fetched)
+	assert.Empty(t, buf.String())
+}
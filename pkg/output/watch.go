@@ -0,0 +1,249 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// DefaultWatchInterval is the time between polls in Watch
+const DefaultWatchInterval = 2 * time.Second
+
+// WatchChange marks how an item changed since the previous poll
+type WatchChange string
+
+const (
+	Added   WatchChange = "Added"
+	Updated WatchChange = "Updated"
+	Removed WatchChange = "Removed"
+)
+
+// changeField is the name of the column marking the changes in table, card and CSV outputs
+const changeField = "Change"
+
+// ListFunc returns the current items of a watched resource
+type ListFunc func(ctx context.Context) ([]interface{}, error)
+
+type WatchOptions struct {
+	// KeyField is the field path identifying an item across polls, ex. "Execution.RunId"
+	KeyField string
+	// Interval is the time between polls. Default - DefaultWatchInterval
+	Interval time.Duration
+}
+
+// Watch polls list on an interval and prints the items added, updated or removed since the previous poll.
+// Items are matched by the key field and compared by value. The first poll prints all items as added.
+// Table, card and CSV outputs mark the changes in the first column, while JSON, YAML and templates
+// print objects with Change and Item fields. On interrupt (Ctrl-C) it returns without an error.
+func Watch(c *cli.Context, list ListFunc, watchOpts *WatchOptions, opts *PrintOptions) error {
+	if watchOpts == nil || watchOpts.KeyField == "" {
+		return fmt.Errorf("unable to watch: key field is not set")
+	}
+
+	interval := watchOpts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	if opts == nil {
+		opts = &PrintOptions{}
+	}
+	opts.layout = &tableLayout{}
+	opts.template = &parsedTemplate{}
+
+	// the change column fits all the marks, as the table layout is kept across polls
+	widths := map[string]int{changeField: len(Updated)}
+	for field, width := range opts.ColumnWidths {
+		widths[field] = width
+	}
+	opts.ColumnWidths = widths
+
+	ctx, stop := notifyInterrupt(c.Context)
+	defer stop()
+
+	return withPager(c, opts, func(w io.Writer) error {
+		return watch(ctx, c, w, list, watchOpts.KeyField, interval, opts)
+	})
+}
+
+func watch(ctx context.Context, c *cli.Context, writer io.Writer, list ListFunc, keyField string, interval time.Duration, opts *PrintOptions) error {
+	state := &watchState{keyField: keyField, items: map[string]watchedItem{}}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		items, err := list(ctx)
+		if err != nil {
+			if isInterrupted(ctx, c) {
+				return nil
+			}
+			return fmt.Errorf("unable to watch: %w", err)
+		}
+
+		if items, err = filterItems(c, items); err != nil {
+			return err
+		}
+		if items, err = sortItems(c, items); err != nil {
+			return err
+		}
+
+		changed, changes, err := state.update(items)
+		if err != nil {
+			return fmt.Errorf("unable to watch: %w", err)
+		}
+
+		if len(changed) > 0 {
+			if err := printChanges(c, writer, changed, changes, opts); err != nil {
+				return err
+			}
+			opts.NoHeader = true
+		}
+
+		select {
+		case <-ctx.Done():
+			if isInterrupted(ctx, c) {
+				return nil
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type watchedItem struct {
+	item  interface{}
+	value string
+}
+
+// watchState keeps the items of the previous poll to find the changes
+type watchState struct {
+	keyField string
+	keys     []string
+	items    map[string]watchedItem
+}
+
+// update replaces the items of the previous poll and returns the items that changed.
+// Added and updated items are returned in the order they are listed, followed by the removed items.
+func (s *watchState) update(items []interface{}) ([]interface{}, []WatchChange, error) {
+	keyValues, err := extractFieldValues(items, []string{s.keyField})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changed []interface{}
+	var changes []WatchChange
+
+	keys := make([]string, len(items))
+	current := make(map[string]watchedItem, len(items))
+	for i, item := range items {
+		keys[i] = fmt.Sprint(normalizeValue(keyValues[i][0]))
+
+		value, err := ParseToJSON(item, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		current[keys[i]] = watchedItem{item: item, value: value}
+
+		if prev, ok := s.items[keys[i]]; !ok {
+			changed = append(changed, item)
+			changes = append(changes, Added)
+		} else if prev.value != value {
+			changed = append(changed, item)
+			changes = append(changes, Updated)
+		}
+	}
+
+	for _, key := range s.keys {
+		if _, ok := current[key]; !ok {
+			changed = append(changed, s.items[key].item)
+			changes = append(changes, Removed)
+		}
+	}
+
+	s.keys = keys
+	s.items = current
+
+	return changed, changes, nil
+}
+
+// watchEvent is a change printed in JSON, YAML and template outputs
+type watchEvent struct {
+	Change WatchChange
+	Item   interface{}
+}
+
+// MarshalJSON encodes the item the same way as when it's printed on its own
+func (e watchEvent) MarshalJSON() ([]byte, error) {
+	item, err := ParseToJSON(e.Item, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"Change":%q,"Item":%v}`, e.Change, item)
+	return buf.Bytes(), nil
+}
+
+func printChanges(c *cli.Context, w io.Writer, items []interface{}, changes []WatchChange, opts *PrintOptions) error {
+	switch getOutputFormat(c, opts) {
+	case Table, Card, CSV, TSV:
+		opts.changes = changes
+		defer func() { opts.changes = nil }()
+		return writeItems(c, w, items, opts)
+	}
+
+	if fields := selectFields(c, opts); len(fields) > 0 {
+		var err error
+		if items, err = projectItems(items, fields); err != nil {
+			return fmt.Errorf("unable to watch: %w", err)
+		}
+	}
+
+	events := make([]interface{}, len(items))
+	for i, item := range items {
+		events[i] = watchEvent{Change: changes[i], Item: item}
+	}
+
+	// fields are already applied to the items
+	eventOpts := *opts
+	eventOpts.ForceFields = true
+
+	if getOutputFormat(c, opts) == JSON {
+		// events are printed one by one, as they are found
+		for _, event := range events {
+			if err := PrintJSON(c, w, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return writeItems(c, w, events, &eventOpts)
+}
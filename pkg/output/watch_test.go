@@ -0,0 +1,154 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/output"
+)
+
+// polls returns a list function over the snapshots, cancelling the watch after the last one
+func polls(cancel context.CancelFunc, snapshots ...[]*dataForPrinter) output.ListFunc {
+	i := 0
+	return func(ctx context.Context) ([]interface{}, error) {
+		snapshot := snapshots[i]
+		if i++; i == len(snapshots) {
+			cancel()
+		}
+
+		items := make([]interface{}, len(snapshot))
+		for j, item := range snapshot {
+			items[j] = item
+		}
+		return items, nil
+	}
+}
+
+func TestWatch(t *testing.T) {
+	snapshots := [][]*dataForPrinter{
+		{{Name: "a", Value: 1}, {Name: "b", Value: 2}},
+		{{Name: "a", Value: 1}, {Name: "b", Value: 2}},
+		{{Name: "b", Value: 3}, {Name: "c", Value: 4}},
+	}
+
+	tests := map[string]struct {
+		args []string
+		want string
+	}{
+		"table": {
+			args: []string{"--fields", "Name,Value"},
+			want: "  Change   Name  Value  \n" +
+				"  Added    a         1  \n" +
+				"  Added    b         2  \n" +
+				"  Updated  b         3  \n" +
+				"  Added    c         4  \n" +
+				"  Removed  a         1  \n",
+		},
+		"jsonl": {
+			args: []string{"--output", "jsonl", "--fields", "Name"},
+			want: `{"Change":"Added","Item":{"Name":"a"}}` + "\n" +
+				`{"Change":"Added","Item":{"Name":"b"}}` + "\n" +
+				`{"Change":"Updated","Item":{"Name":"b"}}` + "\n" +
+				`{"Change":"Added","Item":{"Name":"c"}}` + "\n" +
+				`{"Change":"Removed","Item":{"Name":"a"}}` + "\n",
+		},
+		"template": {
+			args: []string{"--template", "{{.Change}} {{.Item.Name}}"},
+			want: "Added a\nAdded b\nUpdated b\nAdded c\nRemoved a\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, teardown := setupPrinterTest(tt.args...)
+			defer teardown()
+
+			var buf bytes.Buffer
+			c.App.Writer = &buf
+
+			ctx, cancel := context.WithCancel(context.Background())
+			c.Context = ctx
+
+			err := output.Watch(c, polls(cancel, snapshots...), &output.WatchOptions{KeyField: "Name", Interval: time.Millisecond}, &output.PrintOptions{})
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWatch_Color(t *testing.T) {
+	snapshots := [][]*dataForPrinter{
+		{{Name: "a", Value: 1}},
+		{{Name: "b", Value: 2}},
+	}
+
+	c, teardown := setupPrinterTest("--fields", "Name,Value", "--color", "always")
+	defer teardown()
+
+	var buf bytes.Buffer
+	c.App.Writer = &buf
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Context = ctx
+
+	err := output.Watch(c, polls(cancel, snapshots...), &output.WatchOptions{KeyField: "Name", Interval: time.Millisecond}, &output.PrintOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// only the change column is colored, by the roles of the default theme
+	assert.Contains(t, buf.String(), "  \x1b[32mAdded\x1b[0m    b         2  \n")
+	assert.Contains(t, buf.String(), "  \x1b[31mRemoved\x1b[0m  a         1  \n")
+}
+
+func TestWatch_JSON(t *testing.T) {
+	c, teardown := setupPrinterTest("--output", "json", "--fields", "Value")
+	defer teardown()
+
+	var buf bytes.Buffer
+	c.App.Writer = &buf
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Context = ctx
+
+	list := polls(cancel, []*dataForPrinter{{Name: "a", Value: 1}})
+	err := output.Watch(c, list, &output.WatchOptions{KeyField: "Name", Interval: time.Millisecond}, &output.PrintOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "{\n  \"Change\": \"Added\",\n  \"Item\": {\n    \"Value\": 1\n  }\n}\n", buf.String())
+}
+
+func TestWatch_InvalidKey(t *testing.T) {
+	c, teardown := setupPrinterTest()
+	defer teardown()
+
+	list := polls(func() {}, []*dataForPrinter{{Name: "a"}})
+
+	err := output.Watch(c, list, &output.WatchOptions{KeyField: "Missing"}, &output.PrintOptions{})
+	assert.ErrorContains(t, err, "Missing")
+
+	err = output.Watch(c, list, nil, &output.PrintOptions{})
+	assert.EqualError(t, err, "unable to watch: key field is not set")
+}
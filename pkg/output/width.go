@@ -0,0 +1,165 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+const (
+	minColumnWidth = 5 // columns are not truncated to fit the terminal below this width
+	ellipsis       = "…"
+)
+
+// terminalWidth returns the width of the terminal attached to stdout, or 0 if stdout isn't a terminal.
+// The COLUMNS env variable takes precedence.
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	fd := os.Stdout.Fd()
+	if !isatty.IsTerminal(fd) && !isatty.IsCygwinTerminal(fd) {
+		return 0
+	}
+
+	width, _, err := term.GetSize(int(fd))
+	if err != nil {
+		return 0
+	}
+
+	return width
+}
+
+// columnWidths returns the width of each table column: the width declared in PrintOptions.ColumnWidths
+// or the width of the widest cell. Unless wide is set, the widths are limited by PrintOptions.MaxColumnWidths
+// and the columns without declared widths are narrowed to fit into maxWidth if it's set
+func columnWidths(opts *PrintOptions, fields []string, header []string, rows [][]string, maxWidth int, wide bool) []int {
+	widths := make([]int, len(fields))
+	declared := make([]bool, len(fields))
+	for j, field := range fields {
+		if w, ok := opts.ColumnWidths[field]; ok && w > 0 {
+			widths[j] = w
+			declared[j] = true
+			continue
+		}
+
+		if !opts.NoHeader {
+			widths[j] = cellWidth(header[j])
+		}
+		for _, row := range rows {
+			if w := cellWidth(row[j]); w > widths[j] {
+				widths[j] = w
+			}
+		}
+
+		if max, ok := opts.MaxColumnWidths[field]; ok && !wide && max > 0 && widths[j] > max {
+			widths[j] = max
+		}
+	}
+
+	if wide || maxWidth <= 0 || len(widths) == 0 {
+		return widths
+	}
+
+	// columns are padded with a space on both sides, and so is the table
+	width := 2*len(widths) + 2 + runewidth.StringWidth(opts.Separator)*(len(widths)-1)
+	for _, w := range widths {
+		width += w
+	}
+
+	for width > maxWidth {
+		widest := -1
+		for j, w := range widths {
+			if !declared[j] && (widest < 0 || w > widths[widest]) {
+				widest = j
+			}
+		}
+		if widest < 0 || widths[widest] <= minColumnWidth {
+			break
+		}
+
+		widths[widest]--
+		width--
+	}
+
+	return widths
+}
+
+// fitCell truncates the lines of the cell longer than width with an ellipsis, or wraps them if wrap is set
+func fitCell(cell string, width int, wrap bool) string {
+	if width <= 0 || cellWidth(cell) <= width {
+		return cell
+	}
+
+	lines := strings.Split(cell, "\n")
+	var result []string
+	for _, line := range lines {
+		if runewidth.StringWidth(line) <= width {
+			result = append(result, line)
+		} else if wrap {
+			result = append(result, wrapLine(line, width)...)
+		} else {
+			result = append(result, runewidth.Truncate(line, width, ellipsis))
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func wrapLine(line string, width int) []string {
+	var lines []string
+	var current strings.Builder
+	currentWidth := 0
+
+	for _, r := range line {
+		w := runewidth.RuneWidth(r)
+		if currentWidth+w > width && currentWidth > 0 {
+			lines = append(lines, current.String())
+			current.Reset()
+			currentWidth = 0
+		}
+		current.WriteRune(r)
+		currentWidth += w
+	}
+
+	return append(lines, current.String())
+}
+
+// cellWidth returns the width of the widest line in the cell
+func cellWidth(cell string) int {
+	width := 0
+	for _, line := range strings.Split(cell, "\n") {
+		if w := runewidth.StringWidth(line); w > width {
+			width = w
+		}
+	}
+
+	return width
+}
@@ -0,0 +1,92 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// PrintYAML prints each item as a separate YAML document, so that output printed
+// in multiple batches forms a single multi-document YAML stream.
+// If fields are provided, only those fields of the items are printed.
+func PrintYAML(c *cli.Context, w io.Writer, items []interface{}, fields []string) error {
+	if len(fields) > 0 {
+		var err error
+		if items, err = projectItems(items, fields); err != nil {
+			return fmt.Errorf("unable to print yaml: %w", err)
+		}
+	}
+
+	for _, item := range items {
+		yaml, err := ParseToYAML(item)
+		if err != nil {
+			return fmt.Errorf("unable to print yaml: %w", err)
+		}
+
+		if _, err = fmt.Fprintf(w, "---\n%s", yaml); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseToYAML encodes o as YAML. The object is encoded to JSON first, so that
+// proto messages use their JSON field names and the YAML matches the JSON output.
+func ParseToYAML(o interface{}) (string, error) {
+	json, err := ParseToJSON(o, false)
+	if err != nil {
+		return "", err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(json), &node); err != nil {
+		return "", err
+	}
+	resetStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// resetStyle drops the JSON flow style and quoting so that the node is encoded in block style
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		resetStyle(n)
+	}
+}
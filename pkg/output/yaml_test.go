@@ -0,0 +1,96 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output_test
+
+import (
+	"flag"
+	"os"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/temporalio/tctl-kit/pkg/output"
+	"github.com/urfave/cli/v2"
+)
+
+type dataForYAML struct {
+	Name   string
+	Value  string
+	Nested struct {
+		NName  string
+		NValue string
+	}
+}
+
+func setupYAMLTest() (*cli.Context, func()) {
+	app := cli.NewApp()
+	flagSet := flag.FlagSet{}
+	ctx := cli.NewContext(app, &flagSet, nil)
+
+	return ctx, func() {}
+}
+
+func ExamplePrintYAML() {
+	ctx, teardown := setupYAMLTest()
+	defer teardown()
+
+	structItems := []*dataForYAML{
+		{Name: "foo1", Value: "true"},
+		{Name: "foo2", Value: "bar2"},
+	}
+	structItems[0].Nested.NName = "baz1"
+
+	var items []interface{}
+	for _, item := range structItems {
+		items = append(items, item)
+	}
+
+	output.PrintYAML(ctx, os.Stdout, items, []string{"Name", "Value", "Nested.NName"})
+
+	// Output:
+	// ---
+	// Name: foo1
+	// Value: "true"
+	// Nested.NName: baz1
+	// ---
+	// Name: foo2
+	// Value: bar2
+	// Nested.NName: ""
+}
+
+func ExamplePrintYAML_proto() {
+	ctx, teardown := setupYAMLTest()
+	defer teardown()
+
+	item := &types.Struct{
+		Fields: map[string]*types.Value{
+			"workflowId":    {Kind: &types.Value_StringValue{StringValue: "wid"}},
+			"historyLength": {Kind: &types.Value_NumberValue{NumberValue: 10}},
+		},
+	}
+
+	output.PrintYAML(ctx, os.Stdout, []interface{}{item}, nil)
+
+	// Output:
+	// ---
+	// historyLength: 10
+	// workflowId: wid
+}
@@ -0,0 +1,132 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pager
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+// metadataConfig is the key of the pager config in the app metadata
+const metadataConfig = "pager.config"
+
+// presets are the environment variables set for known pagers, unless the user has already set them
+var presets = map[string]map[string]string{
+	// quit if the output fits on one screen, keep colors and don't clear the screen
+	string(Less): {"LESS": "FRX"},
+	// prompt with the keys to continue or quit
+	string(More): {"MORE": "-d"},
+	// squeeze blank lines
+	string(Most): {"MOST_SWITCHES": "-s"},
+	// page the output as plain text
+	string(Bat): {"BAT_PAGING": "always", "BAT_STYLE": "plain"},
+}
+
+// Configure sets the pager config of the app, usually from the config file.
+// The config is shared with subcommands.
+func Configure(app *cli.App, cfg *config.PagerConfig) {
+	if app.Metadata == nil {
+		app.Metadata = map[string]interface{}{}
+	}
+	app.Metadata[metadataConfig] = cfg
+}
+
+// Command returns the pager command set by the user. In order of precedence, it's taken from:
+// the pager flag, the app specific env variable such as $TCTL_PAGER, the pager config, $PAGER or the fallback.
+func Command(c *cli.Context, fallback string) string {
+	if pager := c.String(FlagPager); pager != "" {
+		return pager
+	}
+
+	if name := EnvName(c.App); name != "" {
+		if pager := os.Getenv(name); pager != "" {
+			return pager
+		}
+	}
+
+	if cfg := appConfig(c); cfg != nil && cfg.Command != "" {
+		return cfg.Command
+	}
+
+	if pager := os.Getenv("PAGER"); pager != "" {
+		return pager
+	}
+
+	return fallback
+}
+
+// EnvName returns the name of the app specific pager env variable, ex. TCTL_PAGER for the "tctl" app
+func EnvName(app *cli.App) string {
+	// subcommands run as apps named after the root app, ex. "tctl workflow"
+	fields := strings.Fields(app.Name)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, fields[0])
+
+	return name + "_PAGER"
+}
+
+func appConfig(c *cli.Context) *config.PagerConfig {
+	cfg, _ := c.App.Metadata[metadataConfig].(*config.PagerConfig)
+	return cfg
+}
+
+// pagerEnv returns the environment of the pager: the presets of the pager not set by the user and the config env
+func pagerEnv(c *cli.Context, pager string) []string {
+	env := os.Environ()
+
+	for _, key := range sortedKeys(presets[pager]) {
+		if _, ok := os.LookupEnv(key); !ok {
+			env = append(env, key+"="+presets[pager][key])
+		}
+	}
+
+	if cfg := appConfig(c); cfg != nil {
+		for _, key := range sortedKeys(cfg.Env) {
+			env = append(env, key+"="+cfg.Env[key])
+		}
+	}
+
+	return env
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
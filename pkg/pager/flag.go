@@ -35,4 +35,8 @@ const (
 	Stdout PagerOption = "stdout"
 	Less   PagerOption = "less"
 	More   PagerOption = "more"
+	Most   PagerOption = "most"
+	Bat    PagerOption = "bat"
+	// Interactive prints a page at a time, fetching the next page when the user asks for it
+	Interactive PagerOption = "interactive"
 )
@@ -0,0 +1,99 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pager
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// MorePrompt is shown after each page in the interactive mode
+const MorePrompt = "--- more (n)ext, (q)uit ---"
+
+// PageFunc prints the next page and reports whether there may be more pages
+type PageFunc func() (more bool, err error)
+
+// Paginate prints pages one at a time, asking the user whether to print the next page.
+// The next page is only printed, and so fetched, once the user asks for it.
+// If stdin or stdout isn't a terminal, all pages are printed without asking.
+func Paginate(c *cli.Context, printPage PageFunc) error {
+	prompt := isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+	return paginate(os.Stdin, c.App.Writer, prompt, printPage)
+}
+
+func paginate(in io.Reader, out io.Writer, prompt bool, printPage PageFunc) error {
+	for {
+		more, err := printPage()
+		if err != nil || !more {
+			return err
+		}
+
+		if !prompt {
+			continue
+		}
+
+		next, err := askNext(in, out)
+		if err != nil || !next {
+			return err
+		}
+	}
+}
+
+// askNext shows the prompt and waits for the user to press a key
+func askNext(in io.Reader, out io.Writer) (bool, error) {
+	fmt.Fprint(out, MorePrompt)
+	// erase the prompt, so the next page continues in its place
+	defer fmt.Fprint(out, "\r\033[K")
+
+	// read single keys without waiting for Enter
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return false, fmt.Errorf("unable to read input: %w", err)
+		}
+		defer term.Restore(int(f.Fd()), state)
+	}
+
+	key := make([]byte, 1)
+	for {
+		if _, err := in.Read(key); err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, fmt.Errorf("unable to read input: %w", err)
+		}
+
+		switch key[0] {
+		case 'n', 'N', ' ', '\r', '\n':
+			return true, nil
+		// Ctrl-C and Ctrl-D are read as keys in raw mode
+		case 'q', 'Q', 3, 4:
+			return false, nil
+		}
+	}
+}
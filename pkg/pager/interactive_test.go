@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pager
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		prompt    bool
+		pages     int
+		wantPages int
+		wantOut   string
+	}{
+		"next until the last page": {
+			input: "n ", prompt: true, pages: 3, wantPages: 3,
+			wantOut: "page\n" + MorePrompt + "\r\033[K" + "page\n" + MorePrompt + "\r\033[K" + "page\n",
+		},
+		"quit": {
+			input: "q", prompt: true, pages: 3, wantPages: 1,
+			wantOut: "page\n" + MorePrompt + "\r\033[K",
+		},
+		"ignores other keys": {
+			input: "xq", prompt: true, pages: 3, wantPages: 1,
+			wantOut: "page\n" + MorePrompt + "\r\033[K",
+		},
+		"end of input": {
+			input: "", prompt: true, pages: 3, wantPages: 1,
+			wantOut: "page\n" + MorePrompt + "\r\033[K",
+		},
+		"no prompt": {
+			input: "", prompt: false, pages: 3, wantPages: 3,
+			wantOut: "page\npage\npage\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			printed := 0
+			printPage := func() (bool, error) {
+				printed++
+				out.WriteString("page\n")
+				return printed < tt.pages, nil
+			}
+
+			err := paginate(strings.NewReader(tt.input), &out, tt.prompt, printPage)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPages, printed)
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	errPrint := errors.New("print failed")
+
+	err := paginate(strings.NewReader("n"), &bytes.Buffer{}, true, func() (bool, error) {
+		return true, errPrint
+	})
+	assert.ErrorIs(t, err, errPrint)
+}
@@ -26,13 +26,18 @@ package pager
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
+	"sync"
 	"syscall"
 
 	"github.com/mattn/go-isatty"
+	"github.com/temporalio/tctl-kit/internal/shellwords"
+	"github.com/temporalio/tctl-kit/pkg/color"
 	"github.com/urfave/cli/v2"
 )
 
@@ -40,47 +45,132 @@ const (
 	DefaultListPageSize = 20
 )
 
-// NewPager returns a writer such as stdout, "less", "more" or a pager provided by the user.
-// A user can provide the pager name with a pager flag or env variable.
+// ErrPagerClosed is returned by the pager writer once the pager has exited, ex. when the user quits it early.
+// Printing should stop when it's returned.
+var ErrPagerClosed = errors.New("pager closed")
+
+// NewPager returns a writer such as stdout, "less", "more" or a pager command provided by the user, ex. "less -S".
+// Use Command to find the pager command set by the user.
 // If no pager is provided, it will fall back to stdout.
-func NewPager(c *cli.Context, pager string) (io.Writer, func()) {
+// The returned func waits for the user to exit the pager and returns the error of the pager, if any.
+func NewPager(c *cli.Context, pager string) (io.Writer, func() error) {
 	noPager := c.Bool(FlagNoPager)
-	if noPager || pager == "" || pager == string(Stdout) || !isatty.IsTerminal(os.Stdout.Fd()) {
-		return c.App.Writer, func() {}
+	if noPager || pager == "" || pager == string(Stdout) || pager == string(Interactive) || !isatty.IsTerminal(os.Stdout.Fd()) {
+		return c.App.Writer, func() error { return nil }
 	}
 
-	exe, err := lookupPager(pager)
-	if err != nil {
-		return os.Stdout, func() {}
+	args, err := shellwords.Split(pager)
+	if err != nil || len(args) == 0 {
+		return os.Stdout, func() error { return nil }
 	}
 
-	cmd := exec.Command(exe)
+	exe, err := lookupPager(args[0])
+	if err != nil {
+		return os.Stdout, func() error { return nil }
+	}
 
-	if pager == string(Less) {
-		env := os.Environ()
-		env = append(env, "LESS=FRX")
-		cmd.Env = env
+	writer, close, err := startPager(exe, args[1:], pagerEnv(c, filepath.Base(exe)), os.Stdout)
+	if err != nil {
+		return os.Stdout, func() error { return nil }
 	}
 
-	signal.Ignore(syscall.SIGPIPE)
+	return writer, close
+}
 
-	reader, writer := io.Pipe()
-	cmd.Stdin = reader
-	cmd.Stdout = os.Stdout
+// startPager runs the pager, writing its output to stdout
+func startPager(exe string, args []string, env []string, stdout io.Writer) (io.Writer, func() error, error) {
+	cmd := exec.Command(exe, args...)
+	cmd.Env = env
+	cmd.Stdout = stdout
 	cmd.Stderr = os.Stderr
-	done := make(chan struct{})
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// SIGPIPE is handled while the pager runs, so writing to a closed pager doesn't kill the process
+	sigpipe := make(chan os.Signal, 1)
+	signal.Notify(sigpipe, syscall.SIGPIPE)
+
+	if err := cmd.Start(); err != nil {
+		signal.Stop(sigpipe)
+		return nil, nil, err
+	}
+
+	w := &pagerWriter{w: stdin, done: make(chan struct{}), terminal: color.IsTerminal(stdout)}
+
+	exited := make(chan struct{})
+	var waitErr error
 	go func() {
-		defer close(done)
-		err := cmd.Run()
-		if err != nil {
-			panic(err)
+		waitErr = cmd.Wait()
+		close(exited)
+	}()
+
+	go func() {
+		select {
+		case <-sigpipe:
+		case <-exited:
 		}
+		w.stop()
 	}()
 
-	return writer, func() {
-		writer.Close()
-		<-done
+	return w, func() error {
+		stdin.Close()
+		<-exited
+		signal.Stop(sigpipe)
+
+		return pagerError(exe, waitErr)
+	}, nil
+}
+
+// pagerWriter stops writing once the pager has exited
+type pagerWriter struct {
+	w        io.Writer
+	done     chan struct{}
+	stopOnce sync.Once
+	// terminal is whether the pager prints to a terminal
+	terminal bool
+}
+
+// IsTerminal tells whether colors can be printed to the pager
+func (p *pagerWriter) IsTerminal() bool {
+	return p.terminal
+}
+
+func (p *pagerWriter) Write(b []byte) (int, error) {
+	select {
+	case <-p.done:
+		return 0, ErrPagerClosed
+	default:
+	}
+
+	n, err := p.w.Write(b)
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed) {
+		p.stop()
+		return n, ErrPagerClosed
+	}
+	return n, err
+}
+
+func (p *pagerWriter) stop() {
+	p.stopOnce.Do(func() { close(p.done) })
+}
+
+// pagerError returns the error of the pager process, ignoring the pager being interrupted by the user
+func pagerError(exe string, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() &&
+			(status.Signal() == syscall.SIGINT || status.Signal() == syscall.SIGPIPE) {
+			return nil
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("pager %v failed: %w", exe, err)
 	}
+	return nil
 }
 
 func lookupPager(pagerName string) (string, error) {
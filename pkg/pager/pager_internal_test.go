@@ -0,0 +1,136 @@
+// The MIT License
+//
+// Copyright (c) 2021 Temporal Technologies Inc.  All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package pager
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/temporalio/tctl-kit/pkg/config"
+	"github.com/urfave/cli/v2"
+)
+
+// fakePager writes an executable pager script. Tests using it are skipped on Windows, which can't run shell scripts
+func fakePager(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("no shell scripts on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "pager")
+	err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0700)
+	require.NoError(t, err)
+	return path
+}
+
+func TestStartPager(t *testing.T) {
+	var stdout bytes.Buffer
+	w, close, err := startPager(fakePager(t, "cat"), nil, nil, &stdout)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("hello\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, close())
+	assert.Equal(t, "hello\n", stdout.String())
+}
+
+func TestStartPager_Env(t *testing.T) {
+	var stdout bytes.Buffer
+	w, close, err := startPager(fakePager(t, `cat >/dev/null; echo "$LESS"`), nil, []string{"LESS=FRX"}, &stdout)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("hello\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, close())
+	assert.Equal(t, "FRX\n", stdout.String())
+}
+
+func TestStartPager_Failed(t *testing.T) {
+	w, close, err := startPager(fakePager(t, "cat >/dev/null; exit 3"), nil, nil, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("hello\n"))
+	assert.NoError(t, err)
+
+	err = close()
+	assert.ErrorContains(t, err, "exit status 3")
+	assert.True(t, strings.HasPrefix(err.Error(), "pager "))
+}
+
+func TestStartPager_QuitEarly(t *testing.T) {
+	w, close, err := startPager(fakePager(t, "exit 0"), nil, nil, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	// the writer stops once the pager has exited
+	line := []byte(strings.Repeat("x", 1023) + "\n")
+	deadline := time.Now().Add(5 * time.Second)
+	for err == nil && time.Now().Before(deadline) {
+		_, err = w.Write(line)
+	}
+
+	assert.ErrorIs(t, err, ErrPagerClosed)
+	assert.NoError(t, close())
+
+	_, err = w.Write(line)
+	assert.ErrorIs(t, err, ErrPagerClosed)
+}
+
+func TestPagerEnv(t *testing.T) {
+	t.Setenv("LESS", "")
+	// restored once the test is done
+	t.Setenv("MORE", "")
+	t.Setenv("BAT_STYLE", "")
+	os.Unsetenv("MORE")
+	os.Unsetenv("BAT_STYLE")
+
+	app := cli.NewApp()
+	Configure(app, &config.PagerConfig{Env: map[string]string{"BAT_THEME": "ansi"}})
+	c := cli.NewContext(app, flag.NewFlagSet("test", flag.ContinueOnError), nil)
+
+	// the presets don't override the variables set by the user
+	env := pagerEnv(c, "less")
+	assert.NotContains(t, env, "LESS=FRX")
+	assert.Contains(t, env, "BAT_THEME=ansi")
+
+	assert.Contains(t, pagerEnv(c, "more"), "MORE=-d")
+	assert.Contains(t, pagerEnv(c, "bat"), "BAT_STYLE=plain")
+}
+
+func TestStartPager_Args(t *testing.T) {
+	var stdout bytes.Buffer
+	w, close, err := startPager(fakePager(t, `cat >/dev/null; echo "$@"`), []string{"-S", "two words"}, nil, &stdout)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("hello\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, close())
+	assert.Equal(t, "-S two words\n", stdout.String())
+}
@@ -30,6 +30,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/tctl-kit/pkg/config"
 	"github.com/temporalio/tctl-kit/pkg/pager"
 	"github.com/urfave/cli/v2"
 )
@@ -76,3 +77,48 @@ func TestPrintTable_StdoutNonTTY(t *testing.T) {
 
 	assert.Equal(t, w, os.Stdout)
 }
+
+func TestCommand(t *testing.T) {
+	tests := map[string]struct {
+		flag   string
+		appEnv string
+		config *config.PagerConfig
+		pager  string
+		want   string
+	}{
+		"fallback":             {want: "more"},
+		"$PAGER":               {pager: "less -S", want: "less -S"},
+		"config over $PAGER":   {config: &config.PagerConfig{Command: "bat"}, pager: "less", want: "bat"},
+		"app env over config":  {appEnv: "most", config: &config.PagerConfig{Command: "bat"}, pager: "less", want: "most"},
+		"flag over app env":    {flag: "less -R", appEnv: "most", pager: "less", want: "less -R"},
+		"config without pager": {config: &config.PagerConfig{}, want: "more"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("PAGER", tt.pager)
+			t.Setenv("TCTL_PAGER", tt.appEnv)
+
+			app := cli.NewApp()
+			app.Name = "tctl workflow"
+			if tt.config != nil {
+				pager.Configure(app, tt.config)
+			}
+
+			flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+			flagSet.String(pager.FlagPager, "", "")
+			if tt.flag != "" {
+				flagSet.Parse([]string{"--pager", tt.flag})
+			}
+			ctx := cli.NewContext(app, flagSet, nil)
+
+			assert.Equal(t, tt.want, pager.Command(ctx, "more"))
+		})
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "TCTL_PAGER", pager.EnvName(&cli.App{Name: "tctl"}))
+	assert.Equal(t, "MY_CLI_PAGER", pager.EnvName(&cli.App{Name: "my-cli workflow list"}))
+	assert.Equal(t, "", pager.EnvName(&cli.App{}))
+}